@@ -0,0 +1,229 @@
+package timestamps
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The binary encoding is a version byte, a flags byte and the valid times:
+//
+//	version   1
+//	flags     bit i is set when the i-th time is valid, binaryZoneOffsets when zone offsets follow the times,
+//	          binaryAbsolute when every valid time is written like base
+//	base      the first valid time, varint Unix seconds and uvarint nanoseconds
+//	offsets   every further valid time, varint nanoseconds after base
+//	zones     with binaryZoneOffsets, varint seconds east of UTC for every valid time
+//
+// A Timestamps with only CreatedAt and UpdatedAt an hour apart takes 15 bytes. Times more than about 292 years
+// apart overflow the offsets, those are written with binaryAbsolute.
+//
+// The encoding is reached through TimestampsBinary and DurationBinary rather than MarshalBinary on Timestamps and
+// Duration, which would be promoted into every model embedding them and make gob drop the other fields.
+const (
+	binaryVersion     = 1
+	binaryAbsolute    = 0x40
+	binaryZoneOffsets = 0x80
+)
+
+var ErrInvalidBinary = errors.New("timestamps: invalid binary encoding")
+var ErrBinaryVersion = errors.New("timestamps: unsupported binary encoding version")
+
+type BinaryOptions struct {
+	// ZoneOffset keeps the zone offset of every time, otherwise times are decoded in UTC.
+	// Zone names are not kept, times come back in a fixed zone.
+	ZoneOffset bool
+}
+
+func appendBinaryTimes(b []byte, times []sql.NullTime, options BinaryOptions) []byte {
+	flags := byte(0)
+	for i, t := range times {
+		if t.Valid {
+			flags |= 1 << uint(i)
+		}
+	}
+	if options.ZoneOffset {
+		flags |= binaryZoneOffsets
+	}
+
+	var base time.Time
+	valid := false
+	for _, t := range times {
+		if !t.Valid {
+			continue
+		}
+		if !valid {
+			base, valid = t.Time, true
+		} else if !base.Add(t.Time.Sub(base)).Equal(t.Time) {
+			flags |= binaryAbsolute
+		}
+	}
+	b = append(b, binaryVersion, flags)
+
+	valid = false
+	for _, t := range times {
+		if !t.Valid {
+			continue
+		}
+
+		if !valid || flags&binaryAbsolute != 0 {
+			valid = true
+			b = appendVarint(b, t.Time.Unix())
+			b = appendUvarint(b, uint64(t.Time.Nanosecond()))
+			continue
+		}
+		b = appendVarint(b, int64(t.Time.Sub(base)))
+	}
+
+	if options.ZoneOffset {
+		for _, t := range times {
+			if t.Valid {
+				_, offset := t.Time.Zone()
+				b = appendVarint(b, int64(offset))
+			}
+		}
+	}
+	return b
+}
+
+// readBinaryTimes decodes data into times and returns the options it was written with.
+func readBinaryTimes(data []byte, times []*sql.NullTime) (BinaryOptions, error) {
+	if 2 > len(data) {
+		return BinaryOptions{}, fmt.Errorf("%w: %d bytes", ErrInvalidBinary, len(data))
+	}
+	if data[0] != binaryVersion {
+		return BinaryOptions{}, fmt.Errorf("%w: %d", ErrBinaryVersion, data[0])
+	}
+
+	flags := data[1]
+	if (flags&^(binaryZoneOffsets|binaryAbsolute))>>uint(len(times)) != 0 {
+		return BinaryOptions{}, fmt.Errorf("%w: flags %#x", ErrInvalidBinary, flags)
+	}
+	data = data[2:]
+
+	values := make([]sql.NullTime, len(times))
+	var base time.Time
+	valid := false
+	for i := range values {
+		if flags&(1<<uint(i)) == 0 {
+			continue
+		}
+
+		if !valid || flags&binaryAbsolute != 0 {
+			seconds, n := binary.Varint(data)
+			if n <= 0 {
+				return BinaryOptions{}, fmt.Errorf("%w: truncated time", ErrInvalidBinary)
+			}
+			data = data[n:]
+
+			nanoseconds, n := binary.Uvarint(data)
+			if n <= 0 || nanoseconds >= uint64(time.Second) {
+				return BinaryOptions{}, fmt.Errorf("%w: truncated time", ErrInvalidBinary)
+			}
+			data = data[n:]
+
+			base, valid = time.Unix(seconds, int64(nanoseconds)).UTC(), true
+			values[i] = validTime(base)
+			continue
+		}
+
+		offset, n := binary.Varint(data)
+		if n <= 0 {
+			return BinaryOptions{}, fmt.Errorf("%w: truncated time", ErrInvalidBinary)
+		}
+		data = data[n:]
+		values[i] = validTime(base.Add(time.Duration(offset)))
+	}
+
+	if flags&binaryZoneOffsets != 0 {
+		for i := range values {
+			if !values[i].Valid {
+				continue
+			}
+
+			offset, n := binary.Varint(data)
+			if n <= 0 {
+				return BinaryOptions{}, fmt.Errorf("%w: truncated zone offset", ErrInvalidBinary)
+			}
+			data = data[n:]
+			values[i] = validTime(values[i].Time.In(time.FixedZone("", int(offset))))
+		}
+	}
+
+	if 0 < len(data) {
+		return BinaryOptions{}, fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(data))
+	}
+
+	for i, value := range values {
+		*times[i] = value
+	}
+	return BinaryOptions{ZoneOffset: flags&binaryZoneOffsets != 0}, nil
+}
+
+func appendVarint(b []byte, v int64) []byte {
+	buf := [binary.MaxVarintLen64]byte{}
+	return append(b, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	buf := [binary.MaxVarintLen64]byte{}
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// TimestampsBinary encodes Timestamps with MarshalBinary and UnmarshalBinary, e.g. as a cache value:
+//
+//	data, err := timestamps.TimestampsBinary{Timestamps: record.Timestamps}.MarshalBinary()
+type TimestampsBinary struct {
+	Timestamps
+	// Options are used by MarshalBinary, UnmarshalBinary sets them to those of the data.
+	Options BinaryOptions
+}
+
+// DurationBinary encodes Duration with MarshalBinary and UnmarshalBinary, see TimestampsBinary.
+type DurationBinary struct {
+	Duration
+	Options BinaryOptions
+}
+
+// AppendBinary appends the binary encoding to b, reusing its capacity.
+func (t TimestampsBinary) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinaryTimes(b, []sql.NullTime{t.CreatedAt, t.UpdatedAt, t.DeletedAt}, t.Options), nil
+}
+
+func (t TimestampsBinary) MarshalBinary() ([]byte, error) {
+	return t.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes data, leaving t unchanged on error.
+func (t *TimestampsBinary) UnmarshalBinary(data []byte) error {
+	options, err := readBinaryTimes(data, []*sql.NullTime{&t.CreatedAt, &t.UpdatedAt, &t.DeletedAt})
+	if err != nil {
+		return err
+	}
+	t.Options = options
+	return nil
+}
+
+// AppendBinary appends the binary encoding to b, reusing its capacity.
+func (t DurationBinary) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinaryTimes(b, []sql.NullTime{t.StartedAt, t.EndedAt}, t.Options), nil
+}
+
+func (t DurationBinary) MarshalBinary() ([]byte, error) {
+	return t.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes data, leaving t unchanged on error.
+func (t *DurationBinary) UnmarshalBinary(data []byte) error {
+	options, err := readBinaryTimes(data, []*sql.NullTime{&t.StartedAt, &t.EndedAt})
+	if err != nil {
+		return err
+	}
+	t.Options = options
+	return nil
+}
@@ -0,0 +1,222 @@
+package timestamps
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_binary_Timestamps(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2021, 7, 1, 12, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	stamps := Timestamps{CreatedAt: Time(now), UpdatedAt: Time(now.Add(time.Hour))}
+
+	var _ encoding.BinaryMarshaler = TimestampsBinary{}
+	var _ encoding.BinaryUnmarshaler = &TimestampsBinary{}
+
+	data, err := TimestampsBinary{Timestamps: stamps}.MarshalBinary()
+	a.Nil(err)
+	a.Equal("01039093ea8d0c008080c58bc6d101", hex.EncodeToString(data))
+	a.Equal(15, len(data))
+
+	decoded := TimestampsBinary{Timestamps: Timestamps{DeletedAt: Now()}, Options: BinaryOptions{ZoneOffset: true}}
+	a.Nil(decoded.UnmarshalBinary(data))
+	a.False(decoded.Options.ZoneOffset)
+	a.True(decoded.CreatedAt.Time.Equal(now))
+	a.True(decoded.UpdatedAt.Time.Equal(now.Add(time.Hour)))
+	a.False(decoded.DeletedAt.Valid)
+	a.Equal(time.UTC, decoded.CreatedAt.Time.Location())
+
+	// Zone offsets, nanoseconds and times before the base.
+	stamps = Timestamps{
+		UpdatedAt: Time(time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.FixedZone("CST", 8*3600))),
+		DeletedAt: Time(time.Date(1960, 1, 1, 0, 0, 0, 1, time.FixedZone("EST", -5*3600))),
+	}
+	data, err = TimestampsBinary{Timestamps: stamps, Options: BinaryOptions{ZoneOffset: true}}.MarshalBinary()
+	a.Nil(err)
+
+	decoded = TimestampsBinary{Timestamps: Timestamps{CreatedAt: Now()}}
+	a.Nil(decoded.UnmarshalBinary(data))
+	a.True(decoded.Options.ZoneOffset)
+	a.False(decoded.CreatedAt.Valid)
+	a.Equal("2021-07-01T12:30:00.123456789+08:00", FormatWithLayout(DefaultRFC3339NanoDateLayout, decoded.UpdatedAt))
+	a.Equal("1960-01-01T00:00:00.000000001-05:00", FormatWithLayout(DefaultRFC3339NanoDateLayout, decoded.DeletedAt))
+
+	data, err = TimestampsBinary{}.MarshalBinary()
+	a.Nil(err)
+	a.Equal([]byte{1, 0}, data)
+
+	// Times too far apart for an offset are written absolute.
+	stamps = Timestamps{
+		CreatedAt: Time(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)),
+		UpdatedAt: Time(time.Date(9999, 1, 1, 0, 0, 0, 5, time.FixedZone("CST", 8*3600))),
+	}
+	data, err = TimestampsBinary{Timestamps: stamps, Options: BinaryOptions{ZoneOffset: true}}.AppendBinary([]byte{0xff})
+	a.Nil(err)
+	a.Equal(byte(0xff), data[0])
+	a.Equal(byte(0xc3), data[2])
+
+	decoded = TimestampsBinary{}
+	a.Nil(decoded.UnmarshalBinary(data[1:]))
+	a.True(decoded.CreatedAt.Time.Equal(stamps.CreatedAt.Time))
+	a.Equal("9999-01-01T00:00:00.000000005+08:00", FormatWithLayout(DefaultRFC3339NanoDateLayout, decoded.UpdatedAt))
+}
+
+func Test_binary_Duration(t *testing.T) {
+	a := assert.New(t)
+
+	duration := Duration{
+		StartedAt: Time(time.Date(2021, 7, 1, 9, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 7, 1, 8, 59, 59, 999999999, time.UTC)),
+	}
+
+	var _ encoding.BinaryMarshaler = DurationBinary{}
+	var _ encoding.BinaryUnmarshaler = &DurationBinary{}
+
+	data, err := DurationBinary{Duration: duration}.MarshalBinary()
+	a.Nil(err)
+
+	decoded := DurationBinary{}
+	a.Nil(decoded.UnmarshalBinary(data))
+	a.Equal(int64(-1), decoded.GetDurationLength())
+
+	// Wrapped as a field, gob uses the binary encoding.
+	type cached struct {
+		Duration DurationBinary
+		Name     string
+	}
+	b := &bytes.Buffer{}
+	a.Nil(gob.NewEncoder(b).Encode(cached{Duration: DurationBinary{Duration: duration}, Name: "nightly"}))
+	value := cached{}
+	a.Nil(gob.NewDecoder(b).Decode(&value))
+	a.Equal("nightly", value.Name)
+	a.True(value.Duration.EndedAt.Time.Equal(duration.EndedAt.Time))
+
+	// gob sees the fields of a model embedding Duration, not an encoding of the times alone.
+	type model struct {
+		Duration
+		Name string
+	}
+	b.Reset()
+	a.Nil(gob.NewEncoder(b).Encode(model{Duration: duration, Name: "nightly"}))
+	embedded := model{}
+	a.Nil(gob.NewDecoder(b).Decode(&embedded))
+	a.Equal("nightly", embedded.Name)
+	a.True(embedded.StartedAt.Time.Equal(duration.StartedAt.Time))
+
+	for _, data := range [][]byte{
+		nil,
+		{1},
+		{1, 0x04},
+		{1, 0x01},
+		{1, 0x01, 0x02},
+		{1, 0x01, 0x02, 0x80},
+		{1, 0x03, 0x02, 0x00},
+		{1, 0x81, 0x02, 0x00},
+		{1, 0x43, 0x02, 0x00, 0x02},
+		{1, 0x01, 0x02, 0x00, 0x00},
+		{1, 0x01, 0x02, 0xff, 0xff, 0xff, 0xff, 0x0f},
+	} {
+		a.True(errors.Is(decoded.UnmarshalBinary(data), ErrInvalidBinary), data)
+	}
+	a.True(errors.Is(decoded.UnmarshalBinary([]byte{2, 0}), ErrBinaryVersion))
+	a.True(decoded.StartedAt.Time.Equal(duration.StartedAt.Time))
+}
+
+func benchmarkFixture() Timestamps {
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.UTC)
+	return Timestamps{CreatedAt: Time(now), UpdatedAt: Time(now.Add(90 * time.Minute))}
+}
+
+func Benchmark_binary_MarshalBinary(b *testing.B) {
+	stamps := TimestampsBinary{Timestamps: benchmarkFixture()}
+	b.ResetTimer()
+
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = stamps.MarshalBinary()
+	}
+	b.ReportMetric(float64(len(data)), "bytes/value")
+}
+
+func Benchmark_binary_AppendBinary(b *testing.B) {
+	stamps := TimestampsBinary{Timestamps: benchmarkFixture()}
+	b.ResetTimer()
+
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = stamps.AppendBinary(data[:0])
+	}
+	b.ReportMetric(float64(len(data)), "bytes/value")
+}
+
+func Benchmark_binary_UnmarshalBinary(b *testing.B) {
+	stamps := TimestampsBinary{Timestamps: benchmarkFixture()}
+	data, _ := stamps.MarshalBinary()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = stamps.UnmarshalBinary(data)
+	}
+}
+
+func Benchmark_binary_GobEncode(b *testing.B) {
+	stamps := benchmarkFixture()
+
+	// gob writes the type once per stream, so only values after the first are measured.
+	buf := &bytes.Buffer{}
+	encoder := gob.NewEncoder(buf)
+	_ = encoder.Encode(stamps)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		_ = encoder.Encode(stamps)
+	}
+	b.ReportMetric(float64(buf.Len()), "bytes/value")
+}
+
+func Benchmark_binary_GobDecode(b *testing.B) {
+	stamps := benchmarkFixture()
+
+	buf := &bytes.Buffer{}
+	encoder := gob.NewEncoder(buf)
+	for i := 0; i < b.N+1; i++ {
+		_ = encoder.Encode(stamps)
+	}
+	decoder := gob.NewDecoder(buf)
+	_ = decoder.Decode(&stamps)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = decoder.Decode(&stamps)
+	}
+}
+
+func Benchmark_binary_JSONMarshal(b *testing.B) {
+	stamps := benchmarkFixture()
+	b.ResetTimer()
+
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = json.Marshal(stamps)
+	}
+	b.ReportMetric(float64(len(data)), "bytes/value")
+}
+
+func Benchmark_binary_JSONUnmarshal(b *testing.B) {
+	stamps := benchmarkFixture()
+	data, _ := json.Marshal(stamps)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = json.Unmarshal(data, &stamps)
+	}
+}
@@ -0,0 +1,133 @@
+// Package bsonts encodes the times of package timestamps as BSON datetimes for the MongoDB driver.
+package bsonts
+
+import (
+	"database/sql"
+	"github.com/hughcube-go/timestamps"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"time"
+)
+
+var sqlNullTimeType = reflect.TypeOf(sql.NullTime{})
+var nullTimeType = reflect.TypeOf(timestamps.NullTime{})
+
+// NullTimeCodec encodes sql.NullTime and NullTime as a BSON datetime, an invalid time as null.
+// Timestamps and Duration need no codec of their own, their fields go through this one.
+//
+// BSON datetimes have millisecond precision and are decoded in UTC.
+type NullTimeCodec struct{}
+
+func (c NullTimeCodec) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	t, ok := nullTimeOf(val)
+	if !ok {
+		return bsoncodec.ValueEncoderError{
+			Name:     "NullTimeEncodeValue",
+			Types:    []reflect.Type{sqlNullTimeType, nullTimeType},
+			Received: val,
+		}
+	}
+
+	if !t.Valid {
+		return vw.WriteNull()
+	}
+	return vw.WriteDateTime(int64(primitive.NewDateTimeFromTime(t.Time)))
+}
+
+// DecodeValue also accepts RFC 3339 strings, int64 milliseconds and BSON timestamps, null and undefined make the time invalid.
+func (c NullTimeCodec) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != sqlNullTimeType && val.Type() != nullTimeType) {
+		return bsoncodec.ValueDecoderError{
+			Name:     "NullTimeDecodeValue",
+			Types:    []reflect.Type{sqlNullTimeType, nullTimeType},
+			Received: val,
+		}
+	}
+
+	t := timestamps.NilTime()
+	switch vrType := vr.Type(); vrType {
+	case bsontype.DateTime:
+		if dt, err := vr.ReadDateTime(); err != nil {
+			return err
+		} else {
+			t = sql.NullTime{Time: primitive.DateTime(dt).Time().UTC(), Valid: true}
+		}
+	case bsontype.Int64:
+		if ms, err := vr.ReadInt64(); err != nil {
+			return err
+		} else {
+			t = sql.NullTime{Time: primitive.DateTime(ms).Time().UTC(), Valid: true}
+		}
+	case bsontype.Timestamp:
+		if seconds, _, err := vr.ReadTimestamp(); err != nil {
+			return err
+		} else {
+			t = sql.NullTime{Time: time.Unix(int64(seconds), 0).UTC(), Valid: true}
+		}
+	case bsontype.String:
+		if value, err := vr.ReadString(); err != nil {
+			return err
+		} else if 0 < len(value) {
+			if now, err := time.Parse(time.RFC3339Nano, value); err != nil {
+				return err
+			} else {
+				t = sql.NullTime{Time: now, Valid: true}
+			}
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return err
+		}
+	default:
+		return bsoncodec.ValueDecoderError{
+			Name:     "NullTimeDecodeValue",
+			Types:    []reflect.Type{sqlNullTimeType, nullTimeType},
+			Received: val,
+		}
+	}
+
+	if val.Type() == nullTimeType {
+		val.Set(reflect.ValueOf(timestamps.NewNullTime(t)))
+	} else {
+		val.Set(reflect.ValueOf(t))
+	}
+	return nil
+}
+
+func nullTimeOf(val reflect.Value) (sql.NullTime, bool) {
+	if !val.IsValid() {
+		return timestamps.NilTime(), false
+	}
+
+	switch t := val.Interface().(type) {
+	case sql.NullTime:
+		return t, true
+	case timestamps.NullTime:
+		return t.NullTime, true
+	}
+	return timestamps.NilTime(), false
+}
+
+// RegisterCodecs registers NullTimeCodec for sql.NullTime and NullTime on rb.
+func RegisterCodecs(rb *bsoncodec.RegistryBuilder) *bsoncodec.RegistryBuilder {
+	codec := NullTimeCodec{}
+	return rb.
+		RegisterTypeEncoder(sqlNullTimeType, codec).
+		RegisterTypeDecoder(sqlNullTimeType, codec).
+		RegisterTypeEncoder(nullTimeType, codec).
+		RegisterTypeDecoder(nullTimeType, codec)
+}
+
+// NewRegistry returns the default bson registry with RegisterCodecs applied,
+// for bson.MarshalWithRegistry or options.Client().SetRegistry.
+func NewRegistry() *bsoncodec.Registry {
+	return RegisterCodecs(bson.NewRegistryBuilder()).Build()
+}
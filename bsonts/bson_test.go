@@ -0,0 +1,90 @@
+package bsonts
+
+import (
+	"database/sql"
+	"github.com/hughcube-go/timestamps"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+type bsonModel struct {
+	Name       string                `bson:"name"`
+	Timestamps timestamps.Timestamps `bson:",inline"`
+	Duration   timestamps.Duration   `bson:"duration"`
+	CheckedAt  timestamps.NullTime   `bson:"checked_at"`
+}
+
+func Test_bson_Codecs(t *testing.T) {
+	a := assert.New(t)
+
+	registry := NewRegistry()
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.FixedZone("CST", 8*3600))
+
+	model := bsonModel{
+		Name:       "nightly",
+		Timestamps: timestamps.Timestamps{CreatedAt: timestamps.Time(now)},
+		Duration:   timestamps.Duration{StartedAt: timestamps.Time(now), EndedAt: timestamps.Time(now.Add(time.Hour))},
+		CheckedAt:  timestamps.NewNullTime(timestamps.Time(now)),
+	}
+
+	data, err := bson.MarshalWithRegistry(registry, model)
+	a.Nil(err)
+
+	raw := bson.Raw(data)
+	a.Equal(bsontype.DateTime, raw.Lookup("createdat").Type)
+	a.Equal(now.UnixNano()/int64(time.Millisecond), raw.Lookup("createdat").DateTime())
+	a.Equal(bsontype.Null, raw.Lookup("updatedat").Type)
+	a.Equal(bsontype.Null, raw.Lookup("deletedat").Type)
+	a.Equal(bsontype.DateTime, raw.Lookup("duration", "startedat").Type)
+	a.Equal(bsontype.DateTime, raw.Lookup("checked_at").Type)
+
+	decoded := bsonModel{Timestamps: timestamps.Timestamps{UpdatedAt: timestamps.Now()}}
+	a.Nil(bson.UnmarshalWithRegistry(registry, data, &decoded))
+	a.Equal("nightly", decoded.Name)
+	a.Equal(time.Date(2021, 7, 1, 4, 30, 0, 123000000, time.UTC), decoded.Timestamps.CreatedAt.Time)
+	a.False(decoded.Timestamps.UpdatedAt.Valid)
+	a.False(decoded.Timestamps.DeletedAt.Valid)
+	a.Equal(int64(time.Hour), decoded.Duration.GetDurationLength())
+	a.True(decoded.CheckedAt.Valid)
+	a.True(decoded.CheckedAt.Time.Equal(decoded.Timestamps.CreatedAt.Time))
+}
+
+func Test_bson_DecodeValue(t *testing.T) {
+	a := assert.New(t)
+
+	registry := NewRegistry()
+
+	data, err := bson.Marshal(bson.D{
+		{Key: "createdat", Value: "2021-07-01T12:30:00.5+08:00"},
+		{Key: "updatedat", Value: int64(1625113800000)},
+		{Key: "deletedat", Value: primitive.Undefined{}},
+	})
+	a.Nil(err)
+
+	stamps := timestamps.Timestamps{DeletedAt: timestamps.Now()}
+	a.Nil(bson.UnmarshalWithRegistry(registry, data, &stamps))
+	a.Equal(time.Date(2021, 7, 1, 4, 30, 0, 500000000, time.UTC), stamps.CreatedAt.Time.UTC())
+	a.Equal(time.Date(2021, 7, 1, 4, 30, 0, 0, time.UTC), stamps.UpdatedAt.Time)
+	a.False(stamps.DeletedAt.Valid)
+
+	data, err = bson.Marshal(bson.M{"startedat": primitive.Timestamp{T: 1625113800}})
+	a.Nil(err)
+	duration := timestamps.Duration{}
+	a.Nil(bson.UnmarshalWithRegistry(registry, data, &duration))
+	a.Equal(time.Date(2021, 7, 1, 4, 30, 0, 0, time.UTC), duration.StartedAt.Time)
+
+	for _, value := range []interface{}{"yesterday", int32(1), true} {
+		data, err = bson.Marshal(bson.M{"startedat": value})
+		a.Nil(err)
+		a.NotNil(bson.UnmarshalWithRegistry(registry, data, &duration), value)
+	}
+
+	// Without the codecs sql.NullTime is a nested document.
+	data, err = bson.Marshal(struct{ At sql.NullTime }{timestamps.Time(time.Now())})
+	a.Nil(err)
+	a.Equal(bsontype.EmbeddedDocument, bson.Raw(data).Lookup("at").Type)
+}
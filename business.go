@@ -0,0 +1,250 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+)
+
+var ErrNoWorkingTime = errors.New("timestamps: no working time within the search horizon")
+
+// businessSearchDays bounds the day by day walk of a calendar that has no working time left.
+const businessSearchDays = 3660
+
+// WorkingWindow is a span of wall clock time measured from midnight, To may be 24h for the end of the day.
+type WorkingWindow struct {
+	From time.Duration
+	To   time.Duration
+}
+
+func NewWorkingWindow(from NullTimeOfDay, to NullTimeOfDay) WorkingWindow {
+	window := WorkingWindow{From: from.SinceMidnight, To: to.SinceMidnight}
+	if to.Valid && to.SinceMidnight == 0 {
+		window.To = LengthDay
+	}
+	return window
+}
+
+type BusinessCalendar struct {
+	// Location the windows and holidays are expressed in, nil means time.Local.
+	Location *time.Location
+	// Windows lists the working windows of each weekday, a weekday without windows is a day off.
+	Windows map[time.Weekday][]WorkingWindow
+	// Holidays are days off regardless of their weekday.
+	Holidays map[NullDate]bool
+	// MakeUpDays are working days (调休) that follow the windows of the mapped weekday, they win over Holidays.
+	MakeUpDays map[NullDate]time.Weekday
+}
+
+// NewBusinessCalendar returns a Monday to Friday, 09:00 to 18:00 calendar without holidays.
+func NewBusinessCalendar(loc *time.Location) *BusinessCalendar {
+	c := &BusinessCalendar{Location: loc, Windows: map[time.Weekday][]WorkingWindow{}, Holidays: map[NullDate]bool{}}
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		c.SetWindows(weekday, WorkingWindow{From: 9 * time.Hour, To: 18 * time.Hour})
+	}
+	return c
+}
+
+func (c *BusinessCalendar) SetWindows(weekday time.Weekday, windows ...WorkingWindow) {
+	if c.Windows == nil {
+		c.Windows = map[time.Weekday][]WorkingWindow{}
+	}
+
+	sorted := make([]WorkingWindow, 0, len(windows))
+	for _, window := range windows {
+		if window.From < window.To {
+			sorted = append(sorted, window)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].From < sorted[j].From
+	})
+	c.Windows[weekday] = sorted
+}
+
+func (c *BusinessCalendar) AddHolidays(dates ...NullDate) {
+	if c.Holidays == nil {
+		c.Holidays = map[NullDate]bool{}
+	}
+
+	for _, date := range dates {
+		if date.Valid {
+			c.Holidays[date] = true
+		}
+	}
+}
+
+// AddMakeUpDays turns the dates into working days with the windows of weekday.
+func (c *BusinessCalendar) AddMakeUpDays(weekday time.Weekday, dates ...NullDate) {
+	if c.MakeUpDays == nil {
+		c.MakeUpDays = map[NullDate]time.Weekday{}
+	}
+
+	for _, date := range dates {
+		if date.Valid {
+			c.MakeUpDays[date] = weekday
+		}
+	}
+}
+
+// AddHolidaySet copies the days off and make-up working days of the set, the latter get the windows of makeUpWeekday.
+func (c *BusinessCalendar) AddHolidaySet(set *HolidaySet, makeUpWeekday time.Weekday) {
+	c.AddHolidays(set.DaysOff()...)
+	c.AddMakeUpDays(makeUpWeekday, set.MakeUpDays()...)
+}
+
+func (c *BusinessCalendar) location() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+func (c *BusinessCalendar) IsWorkingDay(date NullDate) bool {
+	return 0 < len(c.windowsOn(date))
+}
+
+func (c *BusinessCalendar) windowsOn(date NullDate) []WorkingWindow {
+	if !date.Valid {
+		return nil
+	}
+	if weekday, ok := c.MakeUpDays[date]; ok {
+		return c.Windows[weekday]
+	}
+	if c.Holidays[date] {
+		return nil
+	}
+	return c.Windows[date.Weekday()]
+}
+
+// spansOn returns the working windows of the date as instants.
+func (c *BusinessCalendar) spansOn(date NullDate) [][2]time.Time {
+	windows := c.windowsOn(date)
+	spans := make([][2]time.Time, 0, len(windows))
+	for _, window := range windows {
+		spans = append(spans, [2]time.Time{c.instant(date, window.From), c.instant(date, window.To)})
+	}
+	return spans
+}
+
+func (c *BusinessCalendar) instant(date NullDate, sinceMidnight time.Duration) time.Time {
+	now, _ := LocalDate(date.Year, date.Month, date.Day, 0, 0, 0, int(sinceMidnight), c.location(), DefaultCalendarPolicy)
+	return now
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (c *BusinessCalendar) IsWorkingTime(t sql.NullTime) bool {
+	if !t.Valid {
+		return false
+	}
+
+	now := t.Time.In(c.location())
+	for _, span := range c.spansOn(DateOf(now)) {
+		if !now.Before(span[0]) && now.Before(span[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkingDuration returns how much working time lies between StartedAt and EndedAt.
+func (c *BusinessCalendar) WorkingDuration(d Duration) time.Duration {
+	if !d.StartedAt.Valid || !d.EndedAt.Valid || !d.StartedAt.Time.Before(d.EndedAt.Time) {
+		return 0
+	}
+
+	start := d.StartedAt.Time.In(c.location())
+	end := d.EndedAt.Time.In(c.location())
+
+	var total time.Duration
+	for date := DateOf(start); !date.After(DateOf(end)); date = date.AddDays(1) {
+		for _, span := range c.spansOn(date) {
+			from, to := span[0], span[1]
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			if from.Before(to) {
+				total += to.Sub(from)
+			}
+		}
+	}
+	return total
+}
+
+// AddWorkingTime moves t by d of working time, backwards when d is negative.
+func (c *BusinessCalendar) AddWorkingTime(t sql.NullTime, d time.Duration) (sql.NullTime, error) {
+	if !t.Valid || d == 0 {
+		return t, nil
+	}
+
+	now := t.Time.In(c.location())
+	date := DateOf(now)
+
+	if d > 0 {
+		for i := 0; i < businessSearchDays; i, date = i+1, date.AddDays(1) {
+			for _, span := range c.spansOn(date) {
+				from := span[0]
+				if from.Before(now) {
+					from = now
+				}
+				if !from.Before(span[1]) {
+					continue
+				}
+				if available := span[1].Sub(from); d <= available {
+					return validTime(from.Add(d)), nil
+				} else {
+					d -= available
+				}
+			}
+		}
+		return NilTime(), ErrNoWorkingTime
+	}
+
+	d = -d
+	for i := 0; i < businessSearchDays; i, date = i+1, date.AddDays(-1) {
+		spans := c.spansOn(date)
+		for j := len(spans) - 1; j >= 0; j-- {
+			to := spans[j][1]
+			if to.After(now) {
+				to = now
+			}
+			if !spans[j][0].Before(to) {
+				continue
+			}
+			if available := to.Sub(spans[j][0]); d <= available {
+				return validTime(to.Add(-d)), nil
+			} else {
+				d -= available
+			}
+		}
+	}
+	return NilTime(), ErrNoWorkingTime
+}
+
+// NextWorkingMoment returns t when it is working time, otherwise the start of the next working window.
+func (c *BusinessCalendar) NextWorkingMoment(t sql.NullTime) (sql.NullTime, error) {
+	if !t.Valid || c.IsWorkingTime(t) {
+		return t, nil
+	}
+
+	now := t.Time.In(c.location())
+	date := DateOf(now)
+	for i := 0; i < businessSearchDays; i, date = i+1, date.AddDays(1) {
+		for _, span := range c.spansOn(date) {
+			if !span[0].Before(now) {
+				return validTime(span[0]), nil
+			}
+		}
+	}
+	return NilTime(), ErrNoWorkingTime
+}
+
+func (t *Duration) GetWorkingLength(calendar *BusinessCalendar) time.Duration {
+	return calendar.WorkingDuration(*t)
+}
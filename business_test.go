@@ -0,0 +1,59 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_business_WorkingDuration(t *testing.T) {
+	a := assert.New(t)
+
+	calendar := NewBusinessCalendar(time.UTC)
+	calendar.SetWindows(time.Monday, WorkingWindow{From: 9 * time.Hour, To: 12 * time.Hour}, WorkingWindow{From: 13 * time.Hour, To: 18 * time.Hour})
+	calendar.AddHolidays(Date(2021, 1, 6))
+
+	// Friday 2021-01-01 17:00 to Wednesday 2021-01-06 10:00, the Wednesday being a holiday.
+	duration := Duration{
+		StartedAt: Time(time.Date(2021, 1, 1, 17, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 1, 6, 10, 0, 0, 0, time.UTC)),
+	}
+	a.Equal(time.Hour+8*time.Hour+9*time.Hour, duration.GetWorkingLength(calendar))
+
+	a.True(calendar.IsWorkingTime(Time(time.Date(2021, 1, 4, 9, 0, 0, 0, time.UTC))))
+	a.False(calendar.IsWorkingTime(Time(time.Date(2021, 1, 4, 12, 30, 0, 0, time.UTC))))
+	a.False(calendar.IsWorkingTime(Time(time.Date(2021, 1, 6, 10, 0, 0, 0, time.UTC))))
+	a.False(calendar.IsWorkingTime(NilTime()))
+}
+
+func Test_business_AddWorkingTime(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	calendar := NewBusinessCalendar(loc)
+
+	friday := Time(time.Date(2021, 1, 8, 16, 0, 0, 0, loc))
+
+	now, err := calendar.AddWorkingTime(friday, 4*time.Hour)
+	a.Nil(err)
+	a.Equal("2021-01-11 11:00:00 +08:00", FormatWithZone(now))
+
+	now, err = calendar.AddWorkingTime(now, -4*time.Hour)
+	a.Nil(err)
+	a.Equal("2021-01-08 16:00:00 +08:00", FormatWithZone(now))
+
+	now, err = calendar.NextWorkingMoment(Time(time.Date(2021, 1, 9, 8, 0, 0, 0, loc)))
+	a.Nil(err)
+	a.Equal("2021-01-11 09:00:00 +08:00", FormatWithZone(now))
+
+	now, err = calendar.NextWorkingMoment(friday)
+	a.Nil(err)
+	a.Equal(friday, now)
+
+	closed := &BusinessCalendar{Location: loc}
+	_, err = closed.AddWorkingTime(friday, time.Hour)
+	a.True(errors.Is(err, ErrNoWorkingTime))
+}
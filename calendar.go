@@ -0,0 +1,154 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrNonexistentLocalTime = errors.New("timestamps: local time does not exist")
+var ErrAmbiguousLocalTime = errors.New("timestamps: local time is ambiguous")
+
+type DSTResolution int
+
+const (
+	// DSTShiftForward moves a skipped wall clock past the gap and picks the later of two repeated instants.
+	DSTShiftForward DSTResolution = iota
+	// DSTShiftBack moves a skipped wall clock before the gap and picks the earlier of two repeated instants.
+	DSTShiftBack
+	DSTError
+)
+
+type CalendarPolicy struct {
+	Nonexistent DSTResolution
+	Ambiguous   DSTResolution
+}
+
+var DefaultCalendarPolicy = CalendarPolicy{
+	Nonexistent: DSTShiftForward,
+	Ambiguous:   DSTShiftBack,
+}
+
+// LocalDate is time.Date with an explicit policy for wall clocks that are skipped or repeated by DST changes.
+func LocalDate(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location, policy CalendarPolicy) (time.Time, error) {
+	wall := time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+
+	_, early := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, late := wall.Add(24 * time.Hour).In(loc).Zone()
+
+	var candidates []time.Time
+	for _, offset := range []int{early, late} {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if sameWallClock(candidate, wall) && (0 >= len(candidates) || !candidates[0].Equal(candidate)) {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 2:
+		first, second := candidates[0], candidates[1]
+		if second.Before(first) {
+			first, second = second, first
+		}
+
+		switch policy.Ambiguous {
+		case DSTShiftForward:
+			return second, nil
+		case DSTShiftBack:
+			return first, nil
+		}
+		return time.Time{}, fmt.Errorf("%w: %s in %s", ErrAmbiguousLocalTime, wall.Format(DefaultFineDateLayout), loc)
+	}
+
+	switch policy.Nonexistent {
+	case DSTShiftForward:
+		return wall.Add(-time.Duration(early) * time.Second).In(loc), nil
+	case DSTShiftBack:
+		return wall.Add(-time.Duration(late) * time.Second).In(loc), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %s in %s", ErrNonexistentLocalTime, wall.Format(DefaultFineDateLayout), loc)
+}
+
+func sameWallClock(t time.Time, wall time.Time) bool {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return wall.Equal(time.Date(year, month, day, hour, min, sec, t.Nanosecond(), time.UTC))
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// AddCalendar adds years, months and days to the wall clock of t in loc (nil means the location of t),
+// keeping the time of day and overflowing months like time.AddDate.
+func AddCalendar(t sql.NullTime, years int, months int, days int, loc *time.Location, policy CalendarPolicy) (sql.NullTime, error) {
+	if !t.Valid {
+		return t, nil
+	}
+
+	if loc == nil {
+		loc = t.Time.Location()
+	}
+	now := t.Time.In(loc)
+
+	year, month, day := now.Date()
+	hour, min, sec := now.Clock()
+
+	target := time.Date(year+years, month+time.Month(months), day+days, 0, 0, 0, 0, time.UTC)
+	if result, err := LocalDate(target.Year(), target.Month(), target.Day(), hour, min, sec, now.Nanosecond(), loc, policy); err != nil {
+		return t, err
+	} else {
+		return validTime(result), nil
+	}
+}
+
+func AddDays(t sql.NullTime, days int, loc *time.Location, policy CalendarPolicy) (sql.NullTime, error) {
+	return AddCalendar(t, 0, 0, days, loc, policy)
+}
+
+func AddMonths(t sql.NullTime, months int, loc *time.Location, policy CalendarPolicy) (sql.NullTime, error) {
+	return AddCalendar(t, 0, months, 0, loc, policy)
+}
+
+func AddYears(t sql.NullTime, years int, loc *time.Location, policy CalendarPolicy) (sql.NullTime, error) {
+	return AddCalendar(t, years, 0, 0, loc, policy)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t *Duration) ExtendDays(days int, loc *time.Location, policy CalendarPolicy) error {
+	if now, err := AddDays(t.EndedAt, days, loc, policy); err != nil {
+		return err
+	} else {
+		t.EndedAt = now
+		return nil
+	}
+}
+
+func (t *Duration) ExtendMonths(months int, loc *time.Location, policy CalendarPolicy) error {
+	if now, err := AddMonths(t.EndedAt, months, loc, policy); err != nil {
+		return err
+	} else {
+		t.EndedAt = now
+		return nil
+	}
+}
+
+func (t *Duration) ShiftDays(days int, loc *time.Location, policy CalendarPolicy) error {
+	startedAt, err := AddDays(t.StartedAt, days, loc, policy)
+	if err != nil {
+		return err
+	}
+
+	endedAt, err := AddDays(t.EndedAt, days, loc, policy)
+	if err != nil {
+		return err
+	}
+
+	t.StartedAt = startedAt
+	t.EndedAt = endedAt
+	return nil
+}
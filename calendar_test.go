@@ -0,0 +1,71 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_calendar_LocalDate(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	// 2021-03-14 02:30 does not exist, 2021-11-07 01:30 happens twice.
+	now, err := LocalDate(2021, 3, 14, 2, 30, 0, 0, loc, CalendarPolicy{Nonexistent: DSTShiftForward})
+	a.Nil(err)
+	a.Equal("2021-03-14 03:30:00 -04:00", now.Format(DefaultDateWithZoneLayout))
+
+	now, err = LocalDate(2021, 3, 14, 2, 30, 0, 0, loc, CalendarPolicy{Nonexistent: DSTShiftBack})
+	a.Nil(err)
+	a.Equal("2021-03-14 01:30:00 -05:00", now.Format(DefaultDateWithZoneLayout))
+
+	_, err = LocalDate(2021, 3, 14, 2, 30, 0, 0, loc, CalendarPolicy{Nonexistent: DSTError})
+	a.True(errors.Is(err, ErrNonexistentLocalTime))
+
+	now, err = LocalDate(2021, 11, 7, 1, 30, 0, 0, loc, CalendarPolicy{Ambiguous: DSTShiftBack})
+	a.Nil(err)
+	a.Equal("2021-11-07 01:30:00 -04:00", now.Format(DefaultDateWithZoneLayout))
+
+	now, err = LocalDate(2021, 11, 7, 1, 30, 0, 0, loc, CalendarPolicy{Ambiguous: DSTShiftForward})
+	a.Nil(err)
+	a.Equal("2021-11-07 01:30:00 -05:00", now.Format(DefaultDateWithZoneLayout))
+
+	_, err = LocalDate(2021, 11, 7, 1, 30, 0, 0, loc, CalendarPolicy{Ambiguous: DSTError})
+	a.True(errors.Is(err, ErrAmbiguousLocalTime))
+}
+
+func Test_calendar_AddDays(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	start := Time(time.Date(2021, 3, 13, 9, 0, 0, 0, loc))
+
+	now, err := AddDays(start, 1, nil, DefaultCalendarPolicy)
+	a.Nil(err)
+	a.Equal("2021-03-14 09:00:00 -04:00", FormatWithZone(now))
+	a.Equal(23*time.Hour, now.Time.Sub(start.Time))
+
+	now, err = AddDays(Time(start.Time.UTC()), 1, loc, DefaultCalendarPolicy)
+	a.Nil(err)
+	a.Equal("2021-03-14 09:00:00 -04:00", FormatWithZone(now))
+
+	now, err = AddMonths(start, 8, loc, DefaultCalendarPolicy)
+	a.Nil(err)
+	a.Equal("2021-11-13 09:00:00 -05:00", FormatWithZone(now))
+
+	now, err = AddYears(NilTime(), 1, loc, DefaultCalendarPolicy)
+	a.Nil(err)
+	a.False(now.Valid)
+
+	duration := Duration{StartedAt: start, EndedAt: Time(time.Date(2021, 3, 13, 18, 0, 0, 0, loc))}
+	a.Nil(duration.ExtendDays(1, nil, DefaultCalendarPolicy))
+	a.Equal("2021-03-14 18:00:00 -04:00", FormatWithZone(duration.EndedAt))
+	a.Nil(duration.ShiftDays(-1, nil, DefaultCalendarPolicy))
+	a.Equal("2021-03-12 09:00:00 -05:00", FormatWithZone(duration.StartedAt))
+	a.Equal("2021-03-13 18:00:00 -05:00", FormatWithZone(duration.EndedAt))
+}
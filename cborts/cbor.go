@@ -0,0 +1,129 @@
+// Package cborts encodes the times of package timestamps for github.com/fxamacker/cbor.
+//
+// The types here wrap those of package timestamps and are meant as named fields, a model embedding one of them
+// would be encoded by its methods alone.
+package cborts
+
+import (
+	"database/sql"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hughcube-go/timestamps"
+	"time"
+)
+
+const (
+	tagDateTimeString = 0
+	tagEpochDateTime  = 1
+	null              = 0xf6
+	undefined         = 0xf7
+	majorTypeShift    = 5
+	majorTypeText     = 3
+	majorTypeTag      = 6
+)
+
+// NullTime writes whole seconds as tag 1 with an integer and other times as tag 0 with an RFC 3339 string,
+// so nanoseconds survive. An invalid time is null.
+type NullTime struct {
+	timestamps.NullTime
+}
+
+func NewNullTime(t sql.NullTime) NullTime {
+	return NullTime{NullTime: timestamps.NewNullTime(t)}
+}
+
+func (t NullTime) MarshalCBOR() ([]byte, error) {
+	if !t.Valid {
+		return []byte{null}, nil
+	}
+
+	if 0 == t.Time.Nanosecond() {
+		return cbor.Marshal(cbor.Tag{Number: tagEpochDateTime, Content: t.Time.Unix()})
+	}
+	return cbor.Marshal(cbor.Tag{Number: tagDateTimeString, Content: t.Time.Format(time.RFC3339Nano)})
+}
+
+// UnmarshalCBOR accepts tag 0 and 1 as well as untagged strings and numbers, epoch times are decoded in UTC.
+func (t *NullTime) UnmarshalCBOR(data []byte) error {
+	if 0 >= len(data) || data[0] == null || data[0] == undefined {
+		*t = NullTime{}
+		return nil
+	}
+
+	var now time.Time
+	if err := cbor.Unmarshal(data, &now); err != nil {
+		return err
+	}
+
+	text := data[0]>>majorTypeShift == majorTypeText
+	if data[0]>>majorTypeShift == majorTypeTag {
+		tag := cbor.RawTag{}
+		if err := tag.UnmarshalCBOR(data); err != nil {
+			return err
+		}
+		text = tag.Number == tagDateTimeString
+	}
+	if !text {
+		now = now.UTC()
+	}
+	*t = NewNullTime(sql.NullTime{Time: now, Valid: true})
+	return nil
+}
+
+// Timestamps is written as a map keyed created_at, updated_at and deleted_at.
+type Timestamps struct {
+	timestamps.Timestamps
+}
+
+type timestampsDocument struct {
+	CreatedAt NullTime `cbor:"created_at"`
+	UpdatedAt NullTime `cbor:"updated_at"`
+	DeletedAt NullTime `cbor:"deleted_at"`
+}
+
+func (t Timestamps) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(timestampsDocument{
+		CreatedAt: NewNullTime(t.CreatedAt),
+		UpdatedAt: NewNullTime(t.UpdatedAt),
+		DeletedAt: NewNullTime(t.DeletedAt),
+	})
+}
+
+func (t *Timestamps) UnmarshalCBOR(data []byte) error {
+	document := timestampsDocument{}
+	if err := cbor.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	t.CreatedAt = document.CreatedAt.NullTime.NullTime
+	t.UpdatedAt = document.UpdatedAt.NullTime.NullTime
+	t.DeletedAt = document.DeletedAt.NullTime.NullTime
+	return nil
+}
+
+// Duration is written as a map keyed started_at and ended_at.
+type Duration struct {
+	timestamps.Duration
+}
+
+type durationDocument struct {
+	StartedAt NullTime `cbor:"started_at"`
+	EndedAt   NullTime `cbor:"ended_at"`
+}
+
+func (t Duration) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(durationDocument{
+		StartedAt: NewNullTime(t.StartedAt),
+		EndedAt:   NewNullTime(t.EndedAt),
+	})
+}
+
+func (t *Duration) UnmarshalCBOR(data []byte) error {
+	document := durationDocument{}
+	if err := cbor.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	t.StartedAt = document.StartedAt.NullTime.NullTime
+	t.EndedAt = document.EndedAt.NullTime.NullTime
+	return nil
+}
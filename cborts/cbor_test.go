@@ -0,0 +1,82 @@
+package cborts
+
+import (
+	"encoding/hex"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hughcube-go/timestamps"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type cborRecord struct {
+	Name       string
+	Timestamps Timestamps
+	Duration   Duration
+	CheckedAt  NullTime
+}
+
+func Test_cbor_NullTime(t *testing.T) {
+	a := assert.New(t)
+
+	seconds := time.Date(2021, 7, 1, 4, 30, 0, 0, time.UTC)
+
+	data, err := cbor.Marshal([]NullTime{NewNullTime(timestamps.Time(seconds)), NewNullTime(timestamps.Time(seconds.Add(5))), {}})
+	a.Nil(err)
+	a.Equal("83c11a60dd44c8c0781e323032312d30372d30315430343a33303a30302e3030303030303030355af6", hex.EncodeToString(data))
+
+	// Other consumers see a plain date/time.
+	var native []*time.Time
+	a.Nil(cbor.Unmarshal(data, &native))
+	a.True(native[0].Equal(seconds))
+	a.True(native[1].Equal(seconds.Add(5)))
+	a.Nil(native[2])
+
+	parsed := NullTime{}
+	for value, expected := range map[string]string{
+		"c11a60dd44c8":         "2021-07-01T04:30:00Z",
+		"1a60dd44c8":           "2021-07-01T04:30:00Z",
+		"c1fb41d8375132200000": "2021-07-01T04:30:00.5Z",
+		"c07819323032312d30372d30315431323a33303a30302b30383a3030": "2021-07-01T12:30:00+08:00",
+		"f7": "",
+	} {
+		data, _ := hex.DecodeString(value)
+		a.Nil(cbor.Unmarshal(data, &parsed), value)
+		a.Equal(expected, parsed.Format(timestamps.DefaultRFC3339NanoDateLayout), value)
+	}
+
+	a.NotNil(cbor.Unmarshal([]byte{0xc2, 0x41, 0x01}, &parsed))
+	a.NotNil(cbor.Unmarshal([]byte{0xf5}, &parsed))
+}
+
+func Test_cbor_Timestamps(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123000000, time.UTC)
+	record := cborRecord{
+		Name: "nightly",
+		Timestamps: Timestamps{timestamps.Timestamps{
+			CreatedAt: timestamps.Time(now),
+			DeletedAt: timestamps.Time(time.Date(1960, 1, 1, 0, 0, 0, 1, time.UTC)),
+		}},
+		Duration:  Duration{timestamps.Duration{StartedAt: timestamps.Time(now)}},
+		CheckedAt: NewNullTime(timestamps.Time(now)),
+	}
+
+	data, err := cbor.Marshal(record)
+	a.Nil(err)
+
+	decoded := cborRecord{Duration: Duration{timestamps.Duration{EndedAt: timestamps.Now()}}}
+	a.Nil(cbor.Unmarshal(data, &decoded))
+	a.Equal("nightly", decoded.Name)
+	a.True(decoded.Timestamps.CreatedAt.Time.Equal(now))
+	a.False(decoded.Timestamps.UpdatedAt.Valid)
+	a.True(decoded.Timestamps.DeletedAt.Time.Equal(record.Timestamps.DeletedAt.Time))
+	a.True(decoded.Duration.StartedAt.Time.Equal(now))
+	a.False(decoded.Duration.EndedAt.Valid)
+	a.True(decoded.CheckedAt.Time.Equal(now))
+
+	var document map[string]interface{}
+	a.Nil(cbor.Unmarshal(data, &document))
+	a.Nil(document["Duration"].(map[interface{}]interface{})["ended_at"])
+}
@@ -0,0 +1,329 @@
+package timestamps
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCronExpression = errors.New("timestamps: invalid cron expression")
+
+// cronSearchYears bounds the search of a schedule that may never fire, such as "0 0 30 2 *".
+const cronSearchYears = 10
+
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+type cronField struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	cronSeconds  = cronField{min: 0, max: 59}
+	cronMinutes  = cronField{min: 0, max: 59}
+	cronHours    = cronField{min: 0, max: 23}
+	cronDays     = cronField{min: 1, max: 31}
+	cronMonths   = cronField{min: 1, max: 12, names: cronMonthNames}
+	cronWeekdays = cronField{min: 0, max: 7, names: cronWeekdayNames}
+)
+
+// CronSchedule is a parsed cron expression, evaluated on the wall clock of Location.
+// A wall clock skipped by DST fires after the gap and a repeated one fires once, on its first pass.
+type CronSchedule struct {
+	Location *time.Location
+
+	expression string
+	seconds    uint64
+	minutes    uint64
+	hours      uint64
+	days       uint64
+	months     uint64
+	weekdays   uint64
+	// anyDay and anyWeekday mark a field starting with "*" or "?", such as "*/2". As in Vixie cron, either
+	// field may match only when neither of them starts so.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// ParseCron reads the expression in time.Local, see ParseCronInLocation.
+func ParseCron(expression string) (*CronSchedule, error) {
+	return ParseCronInLocation(expression, time.Local)
+}
+
+// ParseCronInLocation reads "min hour day month weekday", a leading seconds field, an alias such as "@daily",
+// and a "CRON_TZ=Asia/Shanghai " (or "TZ=") prefix that takes precedence over loc.
+func ParseCronInLocation(expression string, loc *time.Location) (*CronSchedule, error) {
+	s := &CronSchedule{Location: loc, expression: expression}
+	if s.Location == nil {
+		s.Location = time.Local
+	}
+
+	value := strings.TrimSpace(expression)
+	if strings.HasPrefix(value, "CRON_TZ=") || strings.HasPrefix(value, "TZ=") {
+		fields := strings.SplitN(value, " ", 2)
+		zone, err := time.LoadLocation(fields[0][strings.IndexByte(fields[0], '=')+1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCronExpression, err)
+		}
+		s.Location = zone
+		value = ""
+		if 2 == len(fields) {
+			value = strings.TrimSpace(fields[1])
+		}
+	}
+
+	if strings.HasPrefix(value, "@") {
+		alias, ok := cronAliases[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown alias %q", ErrInvalidCronExpression, value)
+		}
+		value = alias
+	}
+
+	fields := strings.Fields(value)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: %q needs 5 or 6 fields", ErrInvalidCronExpression, expression)
+	}
+
+	var err error
+	if s.seconds, err = parseCronField(fields[0], cronSeconds); err != nil {
+		return nil, err
+	}
+	if s.minutes, err = parseCronField(fields[1], cronMinutes); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[2], cronHours); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseCronField(fields[3], cronDays); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[4], cronMonths); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = parseCronField(fields[5], cronWeekdays); err != nil {
+		return nil, err
+	}
+
+	// Sunday is both 0 and 7.
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays |= 1
+	}
+	s.anyDay = fields[3][0] == '*' || fields[3][0] == '?'
+	s.anyWeekday = fields[5][0] == '*' || fields[5][0] == '?'
+	return s, nil
+}
+
+// parseCronField reads lists of "*", "?", "a", "a-b" each optionally followed by "/step".
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		step := 1
+		if index := strings.IndexByte(part, '/'); index >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[index+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: bad step in %q", ErrInvalidCronExpression, value)
+			}
+			part = part[:index]
+		}
+
+		from, to := field.min, field.max
+		switch {
+		case part == "*" || part == "?":
+		case strings.IndexByte(part, '-') > 0:
+			index := strings.IndexByte(part, '-')
+			var err error
+			if from, err = field.parse(part[:index]); err != nil {
+				return 0, err
+			}
+			if to, err = field.parse(part[index+1:]); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if from, err = field.parse(part); err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				to = from
+			}
+		}
+
+		if from > to {
+			return 0, fmt.Errorf("%w: empty range in %q", ErrInvalidCronExpression, value)
+		}
+		for i := from; i <= to; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) parse(value string) (int, error) {
+	if number, ok := f.names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < f.min || number > f.max {
+		return 0, fmt.Errorf("%w: %q is not within %d-%d", ErrInvalidCronExpression, value, f.min, f.max)
+	}
+	return number, nil
+}
+
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (s *CronSchedule) matchDay(wall time.Time) bool {
+	day := s.days&(1<<uint(wall.Day())) != 0
+	weekday := s.weekdays&(1<<uint(wall.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+func (s *CronSchedule) instant(wall time.Time) time.Time {
+	now, _ := LocalDate(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, s.Location, DefaultCalendarPolicy)
+	return now
+}
+
+// Next returns the first fire time after t, or the zero time when there is none within cronSearchYears.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	wall := wallClock(t.In(s.Location)).Truncate(time.Second).Add(time.Second)
+	limit := wall.Year() + cronSearchYears
+
+	for wall.Year() <= limit {
+		year, month, day := wall.Date()
+		hour, min, sec := wall.Clock()
+
+		switch {
+		case s.months&(1<<uint(month)) == 0:
+			wall = time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(wall):
+			wall = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+		case s.hours&(1<<uint(hour)) == 0:
+			wall = time.Date(year, month, day, hour+1, 0, 0, 0, time.UTC)
+		case s.minutes&(1<<uint(min)) == 0:
+			wall = time.Date(year, month, day, hour, min+1, 0, 0, time.UTC)
+		case s.seconds&(1<<uint(sec)) == 0:
+			wall = wall.Add(time.Second)
+		default:
+			if now := s.instant(wall); now.After(t) {
+				return now
+			}
+			wall = wall.Add(time.Second)
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last fire time before t, or the zero time when there is none within cronSearchYears.
+func (s *CronSchedule) Prev(t time.Time) time.Time {
+	wall := wallClock(t.Add(-time.Nanosecond).In(s.Location)).Truncate(time.Second)
+	limit := wall.Year() - cronSearchYears
+
+	for wall.Year() >= limit {
+		year, month, day := wall.Date()
+		hour, min, sec := wall.Clock()
+
+		switch {
+		case s.months&(1<<uint(month)) == 0:
+			wall = time.Date(year, month, 1, 0, 0, -1, 0, time.UTC)
+		case !s.matchDay(wall):
+			wall = time.Date(year, month, day, 0, 0, -1, 0, time.UTC)
+		case s.hours&(1<<uint(hour)) == 0:
+			wall = time.Date(year, month, day, hour, 0, -1, 0, time.UTC)
+		case s.minutes&(1<<uint(min)) == 0:
+			wall = time.Date(year, month, day, hour, min, -1, 0, time.UTC)
+		case s.seconds&(1<<uint(sec)) == 0:
+			wall = wall.Add(-time.Second)
+		default:
+			if now := s.instant(wall); now.Before(t) {
+				return now
+			}
+			wall = wall.Add(-time.Second)
+		}
+	}
+	return time.Time{}
+}
+
+// NextN returns up to n fire times after t.
+func (s *CronSchedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for i := 0; i < n; i++ {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// RunWindow returns the window of the last fire at or before t, lasting expected. It is empty when there is no such fire.
+func (s *CronSchedule) RunWindow(t time.Time, expected time.Duration) Duration {
+	fire := s.Prev(t.Add(time.Nanosecond))
+	if fire.IsZero() {
+		return Duration{}
+	}
+	return Duration{StartedAt: validTime(fire), EndedAt: validTime(fire.Add(expected))}
+}
+
+// NextWindow returns the window of the first fire after t, lasting expected.
+func (s *CronSchedule) NextWindow(t time.Time, expected time.Duration) Duration {
+	fire := s.Next(t)
+	if fire.IsZero() {
+		return Duration{}
+	}
+	return Duration{StartedAt: validTime(fire), EndedAt: validTime(fire.Add(expected))}
+}
+
+// GetCronLateness returns how long the run ended after the window of the fire it started for, zero when on time.
+// A run that has not ended is measured against now.
+func (t *Duration) GetCronLateness(schedule *CronSchedule, expected time.Duration) time.Duration {
+	if !t.StartedAt.Valid {
+		return 0
+	}
+
+	window := schedule.RunWindow(t.StartedAt.Time, expected)
+	if !window.EndedAt.Valid {
+		return 0
+	}
+
+	end := time.Now()
+	if t.EndedAt.Valid {
+		end = t.EndedAt.Time
+	}
+	if lateness := end.Sub(window.EndedAt.Time); lateness > 0 {
+		return lateness
+	}
+	return 0
+}
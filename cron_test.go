@@ -0,0 +1,133 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func cronTimes(times []time.Time, layout string) []string {
+	values := make([]string, 0, len(times))
+	for _, now := range times {
+		values = append(values, now.Format(layout))
+	}
+	return values
+}
+
+func Test_cron_ParseCron(t *testing.T) {
+	a := assert.New(t)
+
+	schedule, err := ParseCronInLocation("*/15 9-17 * * MON-FRI", time.UTC)
+	a.Nil(err)
+	a.Equal("*/15 9-17 * * MON-FRI", schedule.String())
+	a.Equal(time.UTC, schedule.Location)
+
+	schedule, err = ParseCron("CRON_TZ=Asia/Shanghai @daily")
+	a.Nil(err)
+	a.Equal("Asia/Shanghai", schedule.Location.String())
+
+	schedule, err = ParseCron("TZ=America/New_York 30 0 12 * * 7")
+	a.Nil(err)
+	a.Equal("America/New_York", schedule.Location.String())
+
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * FOO *",
+		"@fortnightly",
+		"CRON_TZ=Nowhere/City * * * * *",
+	} {
+		_, err := ParseCron(expression)
+		a.True(errors.Is(err, ErrInvalidCronExpression), expression)
+	}
+}
+
+func Test_cron_Next(t *testing.T) {
+	a := assert.New(t)
+
+	schedule, err := ParseCronInLocation("*/15 9-17 * * MON-FRI", time.UTC)
+	a.Nil(err)
+
+	// Friday 2021-01-01 17:50.
+	friday := time.Date(2021, 1, 1, 17, 50, 0, 0, time.UTC)
+	a.Equal([]string{"01-04 09:00", "01-04 09:15", "01-04 09:30"}, cronTimes(schedule.NextN(friday, 3), "01-02 15:04"))
+	a.Equal(time.Date(2021, 1, 1, 17, 45, 0, 0, time.UTC), schedule.Prev(friday))
+	a.Equal(time.Date(2021, 1, 1, 17, 30, 0, 0, time.UTC), schedule.Prev(time.Date(2021, 1, 1, 17, 45, 0, 0, time.UTC)))
+	a.Equal(time.Date(2021, 1, 1, 17, 45, 0, 0, time.UTC), schedule.Next(time.Date(2021, 1, 1, 17, 30, 0, 1, time.UTC)))
+
+	// With seconds, and day of month or weekday when both are restricted.
+	schedule, err = ParseCronInLocation("30 0 12 13 * 5", time.UTC)
+	a.Nil(err)
+	a.Equal([]string{"2021-08-06 12:00:30", "2021-08-13 12:00:30", "2021-08-20 12:00:30"},
+		cronTimes(schedule.NextN(time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC), 3), "2006-01-02 15:04:05"))
+
+	// A stepped "*" still counts as unrestricted, so both fields have to match: odd days that are Mondays.
+	schedule, err = ParseCronInLocation("0 0 */2 * MON", time.UTC)
+	a.Nil(err)
+	a.Equal([]string{"08-09", "08-23", "09-13"}, cronTimes(schedule.NextN(time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC), 3), "01-02"))
+
+	// Leap days only.
+	schedule, err = ParseCronInLocation("0 0 29 2 *", time.UTC)
+	a.Nil(err)
+	a.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), schedule.Next(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)))
+	a.Equal(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), schedule.Prev(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Never.
+	schedule, err = ParseCronInLocation("0 0 30 2 *", time.UTC)
+	a.Nil(err)
+	a.True(schedule.Next(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+	a.True(schedule.Prev(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+	a.Equal(0, len(schedule.NextN(time.Now(), 3)))
+}
+
+func Test_cron_DST(t *testing.T) {
+	a := assert.New(t)
+
+	schedule, err := ParseCron("CRON_TZ=America/New_York 30 2 * * *")
+	a.Nil(err)
+
+	// 02:30 is skipped on 2021-03-14 and fires after the gap.
+	a.Equal([]string{"03-13 02:30 -0500", "03-14 03:30 -0400", "03-15 02:30 -0400"},
+		cronTimes(schedule.NextN(time.Date(2021, 3, 13, 0, 0, 0, 0, time.UTC), 3), "01-02 15:04 -0700"))
+
+	// 01:30 is repeated on 2021-11-07 and fires once.
+	schedule, err = ParseCron("CRON_TZ=America/New_York 30 1 * * *")
+	a.Nil(err)
+	a.Equal([]string{"11-07 01:30 -0400", "11-08 01:30 -0500"},
+		cronTimes(schedule.NextN(time.Date(2021, 11, 7, 0, 0, 0, 0, time.UTC), 2), "01-02 15:04 -0700"))
+	a.Equal("11-07 01:30 -0400", schedule.Prev(time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC)).Format("01-02 15:04 -0700"))
+}
+
+func Test_cron_RunWindow(t *testing.T) {
+	a := assert.New(t)
+
+	schedule, err := ParseCronInLocation("@hourly", time.UTC)
+	a.Nil(err)
+
+	window := schedule.RunWindow(time.Date(2021, 1, 1, 10, 20, 0, 0, time.UTC), 10*time.Minute)
+	a.Equal(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), window.StartedAt.Time)
+	a.Equal(time.Date(2021, 1, 1, 10, 10, 0, 0, time.UTC), window.EndedAt.Time)
+
+	window = schedule.RunWindow(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), 10*time.Minute)
+	a.Equal(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), window.StartedAt.Time)
+
+	window = schedule.NextWindow(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), 10*time.Minute)
+	a.Equal(time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC), window.StartedAt.Time)
+
+	run := Duration{
+		StartedAt: Time(time.Date(2021, 1, 1, 10, 2, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 1, 1, 10, 15, 0, 0, time.UTC)),
+	}
+	a.Equal(5*time.Minute, run.GetCronLateness(schedule, 10*time.Minute))
+	a.Equal(time.Duration(0), run.GetCronLateness(schedule, 20*time.Minute))
+	a.Equal(time.Duration(0), (&Duration{}).GetCronLateness(schedule, time.Minute))
+}
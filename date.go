@@ -0,0 +1,258 @@
+package timestamps
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NullDate is a calendar date without time of day or zone, for DATE columns.
+type NullDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Valid bool
+}
+
+func NilDate() NullDate {
+	return NullDate{}
+}
+
+func Date(year int, month time.Month, day int) NullDate {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func DateOf(now time.Time) NullDate {
+	year, month, day := now.Date()
+	return NullDate{Year: year, Month: month, Day: day, Valid: true}
+}
+
+func Today(loc *time.Location) NullDate {
+	if loc == nil {
+		loc = time.Local
+	}
+	return DateOf(time.Now().In(loc))
+}
+
+func ParseDate(date string) (NullDate, error) {
+	if 0 >= len(date) {
+		return NilDate(), nil
+	}
+
+	if now, err := time.Parse(DefaultDateOnlyLayout, date); err != nil {
+		return NilDate(), err
+	} else {
+		return DateOf(now), nil
+	}
+}
+
+func FormatDate(date NullDate) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.utc().Format(DefaultDateOnlyLayout)
+}
+
+func (d NullDate) String() string {
+	return FormatDate(d)
+}
+
+func (d NullDate) utc() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// In returns the first instant of the date in loc, nil means time.Local.
+func (d NullDate) In(loc *time.Location) sql.NullTime {
+	if !d.Valid {
+		return NilTime()
+	}
+
+	if loc == nil {
+		loc = time.Local
+	}
+	return validTime(calendarMidnight(d.Year, d.Month, d.Day, loc))
+}
+
+func (d NullDate) Weekday() time.Weekday {
+	return d.utc().Weekday()
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (d NullDate) AddDate(years int, months int, days int) NullDate {
+	if !d.Valid {
+		return d
+	}
+	return DateOf(d.utc().AddDate(years, months, days))
+}
+
+func (d NullDate) AddDays(days int) NullDate {
+	return d.AddDate(0, 0, days)
+}
+
+func (d NullDate) AddMonths(months int) NullDate {
+	return d.AddDate(0, months, 0)
+}
+
+func (d NullDate) AddYears(years int) NullDate {
+	return d.AddDate(years, 0, 0)
+}
+
+// DaysUntil returns other minus d in days, zero when either side is invalid.
+func (d NullDate) DaysUntil(other NullDate) int {
+	if !d.Valid || !other.Valid {
+		return 0
+	}
+	return calendarDays(d.utc(), other.utc())
+}
+
+func (d NullDate) Compare(other NullDate) int {
+	switch {
+	case !d.Valid || !other.Valid:
+		if d.Valid == other.Valid {
+			return 0
+		} else if d.Valid {
+			return 1
+		}
+		return -1
+	case d.utc().Before(other.utc()):
+		return -1
+	case d.utc().After(other.utc()):
+		return 1
+	}
+	return 0
+}
+
+func (d NullDate) Before(other NullDate) bool {
+	return d.Valid && other.Valid && d.Compare(other) < 0
+}
+
+func (d NullDate) After(other NullDate) bool {
+	return d.Valid && other.Valid && d.Compare(other) > 0
+}
+
+func (d NullDate) Equal(other NullDate) bool {
+	return d.Compare(other) == 0
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (d *NullDate) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*d = NilDate()
+		return nil
+	case time.Time:
+		*d = DateOf(value)
+		return nil
+	case []byte:
+		return d.scanString(string(value))
+	case string:
+		return d.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into NullDate", value)
+}
+
+func (d *NullDate) scanString(value string) error {
+	if 10 < len(value) {
+		value = value[:10]
+	}
+
+	if date, err := ParseDate(value); err != nil {
+		return err
+	} else {
+		*d = date
+		return nil
+	}
+}
+
+// Value writes the date as "2006-01-02" so the driver cannot shift it into another day through zone conversion.
+func (d NullDate) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return FormatDate(d), nil
+}
+
+func (d NullDate) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FormatDate(d))
+}
+
+func (d *NullDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = NilDate()
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if date, err := ParseDate(value); err != nil {
+		return err
+	} else {
+		*d = date
+		return nil
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// DateRange is the date analog of Duration, EndedOn is inclusive.
+type DateRange struct {
+	StartedOn NullDate
+	EndedOn   NullDate
+}
+
+// Days returns the number of dates in the range, both bounds included.
+func (r *DateRange) Days() int {
+	if !r.StartedOn.Valid || !r.EndedOn.Valid || r.EndedOn.Before(r.StartedOn) {
+		return 0
+	}
+	return r.StartedOn.DaysUntil(r.EndedOn) + 1
+}
+
+// Contains treats an invalid bound as open.
+func (r *DateRange) Contains(date NullDate) bool {
+	if !date.Valid {
+		return false
+	}
+	return !date.Before(r.StartedOn) && !date.After(r.EndedOn)
+}
+
+func (r *DateRange) Overlaps(other DateRange) bool {
+	return !(r.EndedOn.Before(other.StartedOn) || other.EndedOn.Before(r.StartedOn))
+}
+
+// Duration converts the range into instants of loc, EndedAt is the midnight after EndedOn.
+func (r *DateRange) Duration(loc *time.Location) Duration {
+	return Duration{
+		StartedAt: r.StartedOn.In(loc),
+		EndedAt:   r.EndedOn.AddDays(1).In(loc),
+	}
+}
+
+// DateRangeOf returns the dates touched by the duration in loc, an EndedAt on midnight excludes that day.
+func DateRangeOf(duration Duration, loc *time.Location) DateRange {
+	r := DateRange{}
+	if duration.StartedAt.Valid {
+		r.StartedOn = DateOf(calendarLocation(duration.StartedAt, loc))
+	}
+	if duration.EndedAt.Valid {
+		end := calendarLocation(duration.EndedAt, loc)
+		r.EndedOn = DateOf(end)
+		if floorCalendar(end, CalendarDay).Equal(end) {
+			r.EndedOn = r.EndedOn.AddDays(-1)
+		}
+	}
+	return r
+}
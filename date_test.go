@@ -0,0 +1,100 @@
+package timestamps
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_date_NullDate(t *testing.T) {
+	a := assert.New(t)
+
+	date, err := ParseDate("2021-01-31")
+	a.Nil(err)
+	a.True(date.Valid)
+	a.Equal("2021-03-03", date.AddMonths(1).String())
+	a.Equal("2021-02-01", date.AddDays(1).String())
+	a.Equal(29, date.DaysUntil(Date(2021, 3, 1)))
+	a.True(date.Before(Date(2021, 2, 1)))
+	a.True(date.Equal(Date(2021, 1, 31)))
+	a.Equal(time.Sunday, date.Weekday())
+
+	_, err = ParseDate("2021-02-30")
+	a.NotNil(err)
+
+	a.False(NilDate().AddDays(1).Valid)
+	a.Equal("", NilDate().String())
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+	a.Equal("2021-01-31 00:00:00 +08:00", FormatWithZone(date.In(loc)))
+}
+
+func Test_date_NullDateScanValue(t *testing.T) {
+	a := assert.New(t)
+
+	var date NullDate
+	a.Nil(date.Scan([]byte("2021-07-01")))
+	a.Equal(Date(2021, 7, 1), date)
+
+	a.Nil(date.Scan(time.Date(2021, 7, 2, 0, 0, 0, 0, time.UTC)))
+	a.Equal(Date(2021, 7, 2), date)
+
+	a.Nil(date.Scan("2021-07-03 00:00:00"))
+	a.Equal(Date(2021, 7, 3), date)
+
+	value, err := date.Value()
+	a.Nil(err)
+	a.Equal("2021-07-03", value)
+
+	a.Nil(date.Scan(nil))
+	a.False(date.Valid)
+	value, err = date.Value()
+	a.Nil(err)
+	a.Nil(value)
+
+	a.NotNil(date.Scan(42))
+}
+
+func Test_date_JSON(t *testing.T) {
+	a := assert.New(t)
+
+	type row struct {
+		Birthday NullDate
+		OpensAt  NullTimeOfDay
+		ClosesAt NullTimeOfDay
+	}
+
+	data, err := json.Marshal(row{Birthday: Date(2000, 2, 29), OpensAt: TimeOfDay(9, 30, 0, 0)})
+	a.Nil(err)
+	a.Equal(`{"Birthday":"2000-02-29","OpensAt":"09:30:00","ClosesAt":null}`, string(data))
+
+	decoded := row{}
+	a.Nil(json.Unmarshal(data, &decoded))
+	a.Equal(Date(2000, 2, 29), decoded.Birthday)
+	a.Equal(TimeOfDay(9, 30, 0, 0), decoded.OpensAt)
+	a.False(decoded.ClosesAt.Valid)
+
+	a.NotNil(json.Unmarshal([]byte(`{"Birthday":"yesterday"}`), &decoded))
+}
+
+func Test_date_DateRange(t *testing.T) {
+	a := assert.New(t)
+
+	r := DateRange{StartedOn: Date(2021, 1, 1), EndedOn: Date(2021, 1, 31)}
+	a.Equal(31, r.Days())
+	a.True(r.Contains(Date(2021, 1, 31)))
+	a.False(r.Contains(Date(2021, 2, 1)))
+	a.True(r.Overlaps(DateRange{StartedOn: Date(2021, 1, 31), EndedOn: Date(2021, 2, 5)}))
+	a.False(r.Overlaps(DateRange{StartedOn: Date(2021, 2, 1), EndedOn: Date(2021, 2, 5)}))
+
+	duration := r.Duration(time.UTC)
+	a.Equal("2021-01-01 00:00:00", Format(duration.StartedAt))
+	a.Equal("2021-02-01 00:00:00", Format(duration.EndedAt))
+	a.Equal(r, DateRangeOf(duration, time.UTC))
+
+	open := DateRange{StartedOn: Date(2021, 1, 1)}
+	a.True(open.Contains(Date(2099, 1, 1)))
+	a.Equal(0, open.Days())
+}
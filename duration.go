@@ -78,6 +78,7 @@ func (t *Duration) GetEndedAt() time.Time {
 	return t.EndedAt.Time
 }
 
+// SetStartedAt applies DefaultPrecision, SetStartedAtSqlTime stores the time as it is.
 func (t *Duration) SetStartedAt(now time.Time) {
 	t.StartedAt = Time(now)
 }
@@ -308,21 +309,54 @@ func (t *Duration) SetEndedAtSqlTime(now sql.NullTime) {
 	t.EndedAt = now
 }
 
+func (t *Duration) GetStartedAtNullTime() NullTime {
+	return NewNullTime(t.StartedAt)
+}
+
+func (t *Duration) GetEndedAtNullTime() NullTime {
+	return NewNullTime(t.EndedAt)
+}
+
+////////////////////////////////////////////////
+////////////////////////////////////////////////
+////////////////////////////////////////////////
+func (t *Duration) GetStartedAtRelative() string {
+	return FormatRelative(t.StartedAt, time.Now())
+}
+
+func (t *Duration) GetEndedAtRelative() string {
+	return FormatRelative(t.EndedAt, time.Now())
+}
+
 //////////////////////////////////////////////
 //////////////////////////////////////////////
 //////////////////////////////////////////////
 func (t *Duration) LoadDefaultDurationTimestamps() {
-	if !t.StartedAt.Valid {
-		t.StartedAt = Now()
-	}
+	t.LoadDefaultDurationTimestampsWithPrecision(DefaultPrecision)
 }
 
 func (t *Duration) TouchStartTimestamps() {
-	t.StartedAt = Now()
+	t.TouchStartTimestampsWithPrecision(DefaultPrecision)
 }
 
 func (t *Duration) TouchEndTimestamps() {
-	t.EndedAt = Now()
+	t.TouchEndTimestampsWithPrecision(DefaultPrecision)
+}
+
+// LoadDefaultDurationTimestampsWithPrecision is LoadDefaultDurationTimestamps for a model whose columns differ
+// from DefaultPrecision.
+func (t *Duration) LoadDefaultDurationTimestampsWithPrecision(p Precision) {
+	if !t.StartedAt.Valid {
+		t.StartedAt = p.now()
+	}
+}
+
+func (t *Duration) TouchStartTimestampsWithPrecision(p Precision) {
+	t.StartedAt = p.now()
+}
+
+func (t *Duration) TouchEndTimestampsWithPrecision(p Precision) {
+	t.EndedAt = p.now()
 }
 
 func (t *Duration) IsStartedTime() bool {
@@ -343,3 +377,7 @@ func (t *Duration) GetDurationLength() int64 {
 	}
 	return t.EndedAt.Time.UnixNano() - t.StartedAt.Time.UnixNano()
 }
+
+func (t *Duration) FormatLength(options LengthOptions) string {
+	return FormatLength(time.Duration(t.GetDurationLength()), options)
+}
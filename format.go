@@ -0,0 +1,275 @@
+package timestamps
+
+import (
+	"database/sql"
+	"time"
+)
+
+// The AppendFormat* functions write into b without allocating and append nothing for an invalid time.
+// The default layouts are written by hand, years outside 0000 to 9999 and any other layout go through time.AppendFormat.
+func AppendFormatWithLayout(b []byte, layout string, t sql.NullTime) []byte {
+	if !t.Valid {
+		return b
+	}
+
+	switch layout {
+	case DefaultDateLayout:
+		return AppendFormat(b, t)
+	case DefaultFineDateLayout:
+		return AppendFormatFine(b, t)
+	case DefaultRFC3339DateLayout:
+		return AppendFormatRFC3339(b, t)
+	case DefaultRFC3339NanoDateLayout:
+		return AppendFormatRFC3339Nano(b, t)
+	}
+
+	// A layout that cannot be translated is written by time.AppendFormat as it is.
+	if resolved, err := ResolveLayout(layout); err == nil {
+		layout = resolved
+	}
+	return t.Time.AppendFormat(b, layout)
+}
+
+func AppendFormat(b []byte, t sql.NullTime) []byte {
+	if !t.Valid {
+		return b
+	}
+	if !isFastFormatYear(t.Time) {
+		return t.Time.AppendFormat(b, DefaultDateLayout)
+	}
+	return appendDateTime(b, t.Time, ' ')
+}
+
+func AppendFormatFine(b []byte, t sql.NullTime) []byte {
+	if !t.Valid {
+		return b
+	}
+	if !isFastFormatYear(t.Time) {
+		return t.Time.AppendFormat(b, DefaultFineDateLayout)
+	}
+	return appendFraction(appendDateTime(b, t.Time, ' '), t.Time.Nanosecond())
+}
+
+func AppendFormatRFC3339(b []byte, t sql.NullTime) []byte {
+	if !t.Valid {
+		return b
+	}
+	if !isFastFormatYear(t.Time) {
+		return t.Time.AppendFormat(b, DefaultRFC3339DateLayout)
+	}
+	return appendZone(appendDateTime(b, t.Time, 'T'), t.Time)
+}
+
+func AppendFormatRFC3339Nano(b []byte, t sql.NullTime) []byte {
+	if !t.Valid {
+		return b
+	}
+	if !isFastFormatYear(t.Time) {
+		return t.Time.AppendFormat(b, DefaultRFC3339NanoDateLayout)
+	}
+	return appendZone(appendFraction(appendDateTime(b, t.Time, 'T'), t.Time.Nanosecond()), t.Time)
+}
+
+func isFastFormatYear(now time.Time) bool {
+	year := now.Year()
+	return 0 <= year && year <= 9999
+}
+
+// appendDateTime writes "2006-01-02 15:04:05" with separator between the date and the clock.
+func appendDateTime(b []byte, now time.Time, separator byte) []byte {
+	year, month, day := now.Date()
+	hour, minute, second := now.Clock()
+
+	b = appendDigits(b, year, 4)
+	b = append(b, '-')
+	b = appendDigits(b, int(month), 2)
+	b = append(b, '-')
+	b = appendDigits(b, day, 2)
+	b = append(b, separator)
+	b = appendDigits(b, hour, 2)
+	b = append(b, ':')
+	b = appendDigits(b, minute, 2)
+	b = append(b, ':')
+	return appendDigits(b, second, 2)
+}
+
+// appendFraction writes ".999999999", trailing zeros and a zero fraction are left out.
+func appendFraction(b []byte, nanoseconds int) []byte {
+	if 0 == nanoseconds {
+		return b
+	}
+
+	width := 9
+	for nanoseconds%10 == 0 {
+		nanoseconds /= 10
+		width--
+	}
+	return appendDigits(append(b, '.'), nanoseconds, width)
+}
+
+// appendZone writes "Z07:00", seconds of the offset are dropped like time.Format does.
+func appendZone(b []byte, now time.Time) []byte {
+	_, offset := now.Zone()
+	if 0 == offset {
+		return append(b, 'Z')
+	}
+
+	if offset < 0 {
+		b = append(b, '-')
+		offset = -offset
+	} else {
+		b = append(b, '+')
+	}
+	b = appendDigits(b, offset/3600, 2)
+	b = append(b, ':')
+	return appendDigits(b, offset/60%60, 2)
+}
+
+func appendDigits(b []byte, v int, width int) []byte {
+	buf := [9]byte{}
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	return append(b, buf[:width]...)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// parseFastDateTime reads "2006-01-02 15:04:05" with separator between the date and the clock,
+// optionally followed by a fraction of 1 to 9 digits. ok is false for anything else, including out of range values,
+// so the caller can fall back to time.ParseInLocation and report the same errors.
+func parseFastDateTime(value string, separator byte, fraction bool) (fields fastDateTime, rest string, ok bool) {
+	if 19 > len(value) ||
+		value[4] != '-' || value[7] != '-' || value[10] != separator || value[13] != ':' || value[16] != ':' {
+		return fields, "", false
+	}
+
+	var year, month, day, hour, minute, second int
+	if year, ok = parseFastDigits(value[0:4]); !ok {
+		return fields, "", false
+	}
+	if month, ok = parseFastDigits(value[5:7]); !ok || month < 1 || 12 < month {
+		return fields, "", false
+	}
+	if day, ok = parseFastDigits(value[8:10]); !ok || day < 1 || daysInMonth(year, time.Month(month)) < day {
+		return fields, "", false
+	}
+	if hour, ok = parseFastDigits(value[11:13]); !ok || 23 < hour {
+		return fields, "", false
+	}
+	if minute, ok = parseFastDigits(value[14:16]); !ok || 59 < minute {
+		return fields, "", false
+	}
+	if second, ok = parseFastDigits(value[17:19]); !ok || 59 < second {
+		return fields, "", false
+	}
+	fields = fastDateTime{year: year, month: time.Month(month), day: day, hour: hour, minute: minute, second: second}
+	rest = value[19:]
+
+	if fraction && 0 < len(rest) && rest[0] == '.' {
+		i := 1
+		for i < len(rest) && '0' <= rest[i] && rest[i] <= '9' {
+			i++
+		}
+		if 1 >= i || 10 < i {
+			return fields, "", false
+		}
+
+		nanoseconds, _ := parseFastDigits(rest[1:i])
+		for n := i; n < 10; n++ {
+			nanoseconds *= 10
+		}
+		fields.nanosecond = nanoseconds
+		rest = rest[i:]
+	}
+	return fields, rest, true
+}
+
+type fastDateTime struct {
+	year, day, hour, minute, second, nanosecond int
+	month                                       time.Month
+}
+
+func (f fastDateTime) in(loc *time.Location) time.Time {
+	return time.Date(f.year, f.month, f.day, f.hour, f.minute, f.second, f.nanosecond, loc)
+}
+
+func parseFastDigits(value string) (int, bool) {
+	v := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || '9' < value[i] {
+			return 0, false
+		}
+		v = v*10 + int(value[i]-'0')
+	}
+	return v, true
+}
+
+var fastDaysInMonth = [...]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+func daysInMonth(year int, month time.Month) int {
+	if month == time.February && year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 29
+	}
+	return fastDaysInMonth[month-1]
+}
+
+// parseFast parses the default layouts by hand, ok is false for other layouts and anything unusual.
+func parseFast(layout string, value string, loc *time.Location) (time.Time, bool) {
+	switch layout {
+	case DefaultDateLayout:
+		return parseFastLayout(value, false, loc)
+	case DefaultFineDateLayout:
+		return parseFastLayout(value, true, loc)
+	case DefaultRFC3339DateLayout, DefaultRFC3339NanoDateLayout:
+		return parseFastRFC3339(value, loc)
+	}
+	return time.Time{}, false
+}
+
+// parseFastLayout parses DefaultDateLayout, or DefaultFineDateLayout with fraction, in loc.
+func parseFastLayout(value string, fraction bool, loc *time.Location) (time.Time, bool) {
+	fields, rest, ok := parseFastDateTime(value, ' ', fraction)
+	if !ok || 0 < len(rest) {
+		return time.Time{}, false
+	}
+	return fields.in(loc), true
+}
+
+// parseFastRFC3339 parses RFC 3339 with an optional fraction. Like time.ParseInLocation, an offset that loc has at
+// that time gives a time in loc, any other offset a fixed zone, which is the only case that allocates.
+func parseFastRFC3339(value string, loc *time.Location) (time.Time, bool) {
+	fields, rest, ok := parseFastDateTime(value, 'T', true)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if rest == "Z" {
+		return fields.in(time.UTC), true
+	}
+
+	if 6 != len(rest) || (rest[0] != '+' && rest[0] != '-') || rest[3] != ':' {
+		return time.Time{}, false
+	}
+	hours, ok := parseFastDigits(rest[1:3])
+	if !ok || 23 < hours {
+		return time.Time{}, false
+	}
+	minutes, ok := parseFastDigits(rest[4:6])
+	if !ok || 59 < minutes {
+		return time.Time{}, false
+	}
+
+	offset := hours*3600 + minutes*60
+	if rest[0] == '-' {
+		offset = -offset
+	}
+
+	now := fields.in(time.UTC).Add(-time.Duration(offset) * time.Second)
+	if _, zone := now.In(loc).Zone(); zone == offset {
+		return now.In(loc), true
+	}
+	return now.In(time.FixedZone("", offset)), true
+}
@@ -0,0 +1,208 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+var formatLayouts = []string{
+	DefaultDateLayout,
+	DefaultFineDateLayout,
+	DefaultRFC3339DateLayout,
+	DefaultRFC3339NanoDateLayout,
+}
+
+func Test_format_AppendFormat(t *testing.T) {
+	a := assert.New(t)
+
+	zones := []*time.Location{
+		time.UTC,
+		time.FixedZone("CST", 8*3600),
+		time.FixedZone("NPT", 5*3600+45*60),
+		time.FixedZone("LMT", -(4*3600 + 56*60 + 2)),
+	}
+	times := []time.Time{
+		time.Date(2021, 7, 1, 12, 30, 0, 0, time.UTC),
+		time.Date(2021, 7, 1, 12, 30, 0, 100, time.UTC),
+		time.Date(0, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		times = append(times, time.Unix(random.Int63n(1<<36)-1<<35, random.Int63n(int64(time.Second))))
+	}
+
+	for _, now := range times {
+		for _, zone := range zones {
+			for _, layout := range formatLayouts {
+				a.Equal(now.In(zone).Format(layout), string(AppendFormatWithLayout(nil, layout, Time(now.In(zone)))))
+			}
+		}
+	}
+
+	b := []byte("at ")
+	a.Equal("at 2021-07-01 12:30:00", string(AppendFormat(b, Time(times[0]))))
+	a.Equal("at 2021-07-01", string(AppendFormatWithLayout(b, "php:Y-m-d", Time(times[0]))))
+	a.Equal("at php:N", string(AppendFormatWithLayout(b, "php:N", Time(times[0]))))
+	a.Equal("at ", string(AppendFormatFine(b, NilTime())))
+	a.Equal("", FormatRFC3339Nano(NilTime()))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		b = AppendFormatRFC3339Nano(AppendFormatFine(b[:0], Time(times[1])), Time(times[1].In(zones[1])))
+	})
+	a.Equal(float64(0), allocs)
+}
+
+func Test_format_ParseFast(t *testing.T) {
+	a := assert.New(t)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+	newYork, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	values := []string{
+		"2021-07-01 12:30:00",
+		"2021-07-01 12:30:00.5",
+		"2021-07-01 12:30:00.123456789",
+		"2021-07-01 12:30:00.1234567891",
+		"2021-07-01 12:30:00.",
+		"2021-07-01 12:30:00,5",
+		"2021-07-01 12:30",
+		"2021-07-01 24:00:00",
+		"2021-02-29 00:00:00",
+		"2020-02-29 00:00:00",
+		"2021-13-01 00:00:00",
+		"2021-00-01 00:00:00",
+		"2021-07-01 12:30:60",
+		"2021-07-01 12:60:00",
+		"2021-07-0a 12:30:00",
+		"2021-07-01T12:30:00",
+		"2021-03-14 02:30:00",
+		"2021-07-01 12:30:00 ",
+		"0000-01-01 00:00:00",
+		"2021-07-01T12:30:00Z",
+		"2021-07-01T12:30:00.5Z",
+		"2021-07-01T12:30:00+08:00",
+		"2021-07-01T12:30:00-04:00",
+		"2021-12-01T12:30:00-04:00",
+		"2021-07-01T12:30:00+00:00",
+		"2021-07-01T12:30:00-00:00",
+		"2021-07-01T12:30:00+05:45",
+		"2021-07-01T12:30:00.123456789+08:00",
+		"2021-07-01T12:30:00+0800",
+		"2021-07-01T12:30:00+24:00",
+		"2021-07-01T12:30:00+08:60",
+		"2021-07-01T12:30:00z",
+		"2021-07-01T12:30:00",
+		"2021-07-01 12:30:00Z",
+	}
+
+	for _, loc := range []*time.Location{time.UTC, shanghai, newYork} {
+		for _, layout := range formatLayouts {
+			for _, value := range values {
+				expected, expectedErr := time.ParseInLocation(layout, value, loc)
+				actual, err := ParseWithLayoutInLocation(layout, value, loc)
+				if expectedErr != nil {
+					a.NotNil(err, value)
+					a.Equal(expectedErr.Error(), err.Error(), value)
+					continue
+				}
+
+				a.Nil(err, value)
+				a.True(expected.Equal(actual.Time), value)
+				a.Equal(expected.Format(time.RFC3339Nano+" MST"), actual.Time.Format(time.RFC3339Nano+" MST"), value)
+				a.Equal(expected.Location().String(), actual.Time.Location().String(), value)
+			}
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ParseWithLayoutInLocation(DefaultFineDateLayout, "2021-07-01 12:30:00.5", shanghai)
+		_, _ = ParseWithLayoutInLocation(DefaultRFC3339DateLayout, "2021-07-01T12:30:00+08:00", shanghai)
+		_, _ = ParseRFC3339Nano("2021-07-01T12:30:00.5Z")
+	})
+	a.Equal(float64(0), allocs)
+}
+
+func benchmarkFormatTime() time.Time {
+	return time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.FixedZone("CST", 8*3600))
+}
+
+func Benchmark_format_TimeFormat(b *testing.B) {
+	now := benchmarkFormatTime()
+	for i := 0; i < b.N; i++ {
+		_ = now.Format(DefaultFineDateLayout)
+	}
+}
+
+func Benchmark_format_FormatFine(b *testing.B) {
+	now := Time(benchmarkFormatTime())
+	for i := 0; i < b.N; i++ {
+		_ = FormatFine(now)
+	}
+}
+
+func Benchmark_format_TimeAppendFormat(b *testing.B) {
+	now := benchmarkFormatTime()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = now.AppendFormat(buf[:0], DefaultFineDateLayout)
+	}
+}
+
+func Benchmark_format_AppendFormatFine(b *testing.B) {
+	now := Time(benchmarkFormatTime())
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendFormatFine(buf[:0], now)
+	}
+}
+
+func Benchmark_format_AppendFormatRFC3339Nano(b *testing.B) {
+	now := Time(benchmarkFormatTime())
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendFormatRFC3339Nano(buf[:0], now)
+	}
+}
+
+func Benchmark_format_TimeParseInLocation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = time.ParseInLocation(DefaultDateLayout, "2021-07-01 12:30:00", time.Local)
+	}
+}
+
+func Benchmark_format_Parse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse("2021-07-01 12:30:00")
+	}
+}
+
+func Benchmark_format_TimeParseFine(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = time.ParseInLocation(DefaultFineDateLayout, "2021-07-01 12:30:00.123456789", time.Local)
+	}
+}
+
+func Benchmark_format_ParseFine(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseFine("2021-07-01 12:30:00.123456789")
+	}
+}
+
+func Benchmark_format_TimeParseRFC3339(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = time.Parse(time.RFC3339Nano, "2021-07-01T12:30:00.123456789Z")
+	}
+}
+
+func Benchmark_format_ParseRFC3339(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseRFC3339Nano("2021-07-01T12:30:00.123456789Z")
+	}
+}
@@ -2,4 +2,12 @@ module github.com/hughcube-go/timestamps
 
 go 1.13
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/BurntSushi/toml v1.1.0
+	github.com/fxamacker/cbor/v2 v2.3.0
+	github.com/stretchr/testify v1.6.1
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	go.mongodb.org/mongo-driver v1.4.6
+	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
+)
@@ -0,0 +1,293 @@
+package timestamps
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrInvalidHoliday = errors.New("timestamps: invalid holiday")
+
+// Holiday is a named range of days off, or of make-up working days (调休) when Working is set.
+type Holiday struct {
+	DateRange
+	Name    string
+	Region  string
+	Working bool
+}
+
+// HolidaySet is a collection of holidays, days off and make-up working days may come from different sources.
+type HolidaySet struct {
+	holidays []Holiday
+}
+
+func NewHolidaySet(holidays ...Holiday) *HolidaySet {
+	s := &HolidaySet{}
+	s.Add(holidays...)
+	return s
+}
+
+// Add ignores holidays without both bounds and keeps the set ordered by StartedOn.
+func (s *HolidaySet) Add(holidays ...Holiday) {
+	for _, holiday := range holidays {
+		if 0 < holiday.Days() && !s.has(holiday) {
+			s.holidays = append(s.holidays, holiday)
+		}
+	}
+
+	sort.SliceStable(s.holidays, func(i, j int) bool {
+		return s.holidays[i].StartedOn.Before(s.holidays[j].StartedOn)
+	})
+}
+
+func (s *HolidaySet) has(holiday Holiday) bool {
+	for _, other := range s.holidays {
+		if other == holiday {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns a new set holding the holidays of s and others.
+func (s *HolidaySet) Merge(others ...*HolidaySet) *HolidaySet {
+	merged := NewHolidaySet(s.holidays...)
+	for _, other := range others {
+		merged.Add(other.holidays...)
+	}
+	return merged
+}
+
+// Region returns the holidays of the region together with the ones that have no region.
+func (s *HolidaySet) Region(region string) *HolidaySet {
+	filtered := &HolidaySet{}
+	for _, holiday := range s.holidays {
+		if 0 >= len(holiday.Region) || strings.EqualFold(holiday.Region, region) {
+			filtered.holidays = append(filtered.holidays, holiday)
+		}
+	}
+	return filtered
+}
+
+func (s *HolidaySet) Len() int {
+	return len(s.holidays)
+}
+
+func (s *HolidaySet) Holidays() []Holiday {
+	return append([]Holiday(nil), s.holidays...)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Lookup returns the holiday covering the date, a make-up working day wins over a day off.
+func (s *HolidaySet) Lookup(date NullDate) (Holiday, bool) {
+	found := Holiday{}
+	ok := false
+	for _, holiday := range s.holidays {
+		if holiday.Contains(date) && (!ok || holiday.Working) {
+			found, ok = holiday, true
+		}
+	}
+	return found, ok
+}
+
+func (s *HolidaySet) IsDayOff(date NullDate) bool {
+	holiday, ok := s.Lookup(date)
+	return ok && !holiday.Working
+}
+
+func (s *HolidaySet) IsMakeUpDay(date NullDate) bool {
+	holiday, ok := s.Lookup(date)
+	return ok && holiday.Working
+}
+
+// Between returns the holidays touching the duration in loc, nil means the location of the duration.
+func (s *HolidaySet) Between(d Duration, loc *time.Location) []Holiday {
+	dates := DateRangeOf(d, loc)
+
+	var holidays []Holiday
+	for _, holiday := range s.holidays {
+		if holiday.Overlaps(dates) {
+			holidays = append(holidays, holiday)
+		}
+	}
+	return holidays
+}
+
+// DaysOff returns every date off in order, dates also listed as make-up working days are left out.
+func (s *HolidaySet) DaysOff() []NullDate {
+	return s.dates(false)
+}
+
+func (s *HolidaySet) MakeUpDays() []NullDate {
+	return s.dates(true)
+}
+
+func (s *HolidaySet) dates(working bool) []NullDate {
+	seen := map[NullDate]bool{}
+	var dates []NullDate
+	for _, holiday := range s.holidays {
+		for date := holiday.StartedOn; !date.After(holiday.EndedOn); date = date.AddDays(1) {
+			if !seen[date] && s.IsMakeUpDay(date) == working {
+				seen[date] = true
+				dates = append(dates, date)
+			}
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	return dates
+}
+
+// Intervals merges consecutive days off into durations of loc (nil means time.Local).
+func (s *HolidaySet) Intervals(loc *time.Location) []Duration {
+	var intervals []Duration
+	var current DateRange
+	for _, date := range s.DaysOff() {
+		if current.EndedOn.Valid && current.EndedOn.AddDays(1).Equal(date) {
+			current.EndedOn = date
+			continue
+		}
+		if current.StartedOn.Valid {
+			intervals = append(intervals, current.Duration(loc))
+		}
+		current = DateRange{StartedOn: date, EndedOn: date}
+	}
+	if current.StartedOn.Valid {
+		intervals = append(intervals, current.Duration(loc))
+	}
+	return intervals
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// LoadHolidaysCSV reads "date,name,region[,type]" rows. The date is "2006-01-02" or a "2006-01-02/2006-01-02"
+// range with an inclusive end, a type of "workday", "working", "班" or "补班" marks a make-up working day.
+// A first row starting with "date" is taken as header.
+func LoadHolidaysCSV(r io.Reader) (*HolidaySet, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &HolidaySet{}
+	for i, record := range records {
+		if i == 0 && 0 < len(record) && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		if 3 > len(record) {
+			return nil, fmt.Errorf("%w: line %d needs date, name and region", ErrInvalidHoliday, i+1)
+		}
+
+		holiday := Holiday{Name: strings.TrimSpace(record[1]), Region: strings.TrimSpace(record[2])}
+		if holiday.DateRange, err = parseHolidayDates(strings.TrimSpace(record[0])); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidHoliday, i+1, err)
+		}
+		if 3 < len(record) {
+			switch strings.ToLower(strings.TrimSpace(record[3])) {
+			case "", "holiday", "off", "休":
+			case "workday", "working", "班", "补班":
+				holiday.Working = true
+			default:
+				return nil, fmt.Errorf("%w: line %d has unknown type %q", ErrInvalidHoliday, i+1, record[3])
+			}
+		}
+		s.Add(holiday)
+	}
+	return s, nil
+}
+
+func parseHolidayDates(value string) (DateRange, error) {
+	start, end := value, value
+	if index := strings.IndexByte(value, '/'); index >= 0 {
+		start, end = value[:index], value[index+1:]
+	}
+
+	r := DateRange{}
+	var err error
+	if r.StartedOn, err = ParseDate(start); err != nil {
+		return r, err
+	}
+	if r.EndedOn, err = ParseDate(end); err != nil {
+		return r, err
+	}
+	if !r.StartedOn.Valid || r.EndedOn.Before(r.StartedOn) {
+		return r, fmt.Errorf("empty date range %q", value)
+	}
+	return r, nil
+}
+
+func LoadHolidaysCSVFile(path string) (*HolidaySet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadHolidaysCSV(f)
+}
+
+// LoadHolidaysICS reads the VEVENTs of an iCalendar file as holidays of region. An event is a make-up
+// working day when its CATEGORIES contain WORKDAY or its SUMMARY contains "补班" or "上班".
+func LoadHolidaysICS(r io.Reader, region string) (*HolidaySet, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := parseICalComponents(string(data), "VEVENT")
+	if err != nil {
+		return nil, err
+	}
+	zones, err := icalZonesOf(string(data), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &HolidaySet{}
+	for _, event := range events {
+		holiday := Holiday{Name: event.text("SUMMARY"), Region: region}
+		if holiday.DateRange, err = icalEventDates(&event, zones); err != nil {
+			return nil, err
+		}
+		holiday.Working = strings.Contains(strings.ToUpper(event.text("CATEGORIES")), "WORKDAY") ||
+			strings.Contains(holiday.Name, "补班") || strings.Contains(holiday.Name, "上班")
+		s.Add(holiday)
+	}
+	return s, nil
+}
+
+// icalEventDates returns the dates of an event, the DTEND of an all-day event being exclusive.
+func icalEventDates(event *icalComponent, zones icalZones) (DateRange, error) {
+	start, end, allDay, err := icalEventSpan(event, zones)
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	if allDay || !end.Equal(start) {
+		return DateRangeOf(Duration{StartedAt: validTime(start), EndedAt: validTime(end)}, nil), nil
+	}
+	return DateRange{StartedOn: DateOf(start), EndedOn: DateOf(start)}, nil
+}
+
+func LoadHolidaysICSFile(path string, region string) (*HolidaySet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadHolidaysICS(f, region)
+}
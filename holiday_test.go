@@ -0,0 +1,167 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const holidayCSV = `date,name,region,type
+2021-10-01/2021-10-07,国庆节,CN
+2021-09-26,国庆节补班,CN,班
+2021-10-09,国庆节补班,CN,workday
+2021-07-04,Independence Day,US
+2021-12-25,Christmas,
+`
+
+const holidayICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20210919\r\n" +
+	"DTEND;VALUE=DATE:20210922\r\n" +
+	"SUMMARY:中秋节\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20210918\r\n" +
+	"SUMMARY:中秋节 补班\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Asia/Shanghai:20211001T000000\r\n" +
+	"DURATION:P7D\r\n" +
+	"SUMMARY:国庆节\\, 长\r\n" +
+	" 假\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func Test_holiday_LoadHolidaysCSV(t *testing.T) {
+	a := assert.New(t)
+
+	set, err := LoadHolidaysCSV(strings.NewReader(holidayCSV))
+	a.Nil(err)
+	a.Equal(5, set.Len())
+
+	a.True(set.IsDayOff(Date(2021, 10, 1)))
+	a.True(set.IsDayOff(Date(2021, 10, 7)))
+	a.False(set.IsDayOff(Date(2021, 10, 8)))
+	a.True(set.IsMakeUpDay(Date(2021, 9, 26)))
+	a.True(set.IsMakeUpDay(Date(2021, 10, 9)))
+
+	holiday, ok := set.Lookup(Date(2021, 10, 3))
+	a.True(ok)
+	a.Equal("国庆节", holiday.Name)
+	a.Equal(7, holiday.Days())
+
+	cn := set.Region("cn")
+	a.Equal(4, cn.Len())
+	a.False(cn.IsDayOff(Date(2021, 7, 4)))
+	a.True(cn.IsDayOff(Date(2021, 12, 25)))
+
+	_, err = LoadHolidaysCSV(strings.NewReader("2021-10-01,National Day\n"))
+	a.True(errors.Is(err, ErrInvalidHoliday))
+	_, err = LoadHolidaysCSV(strings.NewReader("2021-10-07/2021-10-01,National Day,CN\n"))
+	a.True(errors.Is(err, ErrInvalidHoliday))
+	_, err = LoadHolidaysCSV(strings.NewReader("2021-10-01,National Day,CN,maybe\n"))
+	a.True(errors.Is(err, ErrInvalidHoliday))
+}
+
+func Test_holiday_LoadHolidaysICS(t *testing.T) {
+	a := assert.New(t)
+
+	set, err := LoadHolidaysICS(strings.NewReader(holidayICS), "CN")
+	a.Nil(err)
+	a.Equal(3, set.Len())
+
+	holidays := set.Holidays()
+	a.Equal("中秋节 补班", holidays[0].Name)
+	a.True(holidays[0].Working)
+	a.Equal(1, holidays[0].Days())
+	a.Equal(DateRange{StartedOn: Date(2021, 9, 19), EndedOn: Date(2021, 9, 21)}, holidays[1].DateRange)
+	a.Equal("国庆节, 长假", holidays[2].Name)
+	a.Equal(DateRange{StartedOn: Date(2021, 10, 1), EndedOn: Date(2021, 10, 7)}, holidays[2].DateRange)
+	a.Equal("CN", holidays[2].Region)
+
+	_, err = LoadHolidaysICS(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:x\r\nEND:VEVENT\r\n"), "CN")
+	a.True(errors.Is(err, ErrInvalidICalendar))
+	_, err = LoadHolidaysICS(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"), "CN")
+	a.True(errors.Is(err, ErrInvalidICalendar))
+}
+
+func Test_holiday_Merge(t *testing.T) {
+	a := assert.New(t)
+
+	csv, err := LoadHolidaysCSV(strings.NewReader(holidayCSV))
+	a.Nil(err)
+	ics, err := LoadHolidaysICS(strings.NewReader(holidayICS), "CN")
+	a.Nil(err)
+
+	set := csv.Region("CN").Merge(ics)
+	a.Equal(7, set.Len())
+	a.Equal(4, csv.Region("CN").Len())
+
+	days := func(start NullDate, end NullDate) Duration {
+		r := DateRange{StartedOn: start, EndedOn: end}
+		return r.Duration(time.UTC)
+	}
+
+	intervals := set.Intervals(time.UTC)
+	a.Equal([]Duration{
+		days(Date(2021, 9, 19), Date(2021, 9, 21)),
+		days(Date(2021, 10, 1), Date(2021, 10, 7)),
+		days(Date(2021, 12, 25), Date(2021, 12, 25)),
+	}, intervals)
+	a.Equal([]NullDate{Date(2021, 9, 18), Date(2021, 9, 26), Date(2021, 10, 9)}, set.MakeUpDays())
+
+	october := Date(2021, 10, 1).In(time.UTC)
+	between := set.Between(Duration{StartedAt: october, EndedAt: Date(2021, 11, 1).In(time.UTC)}, nil)
+	a.Equal(3, len(between))
+
+	// A make-up working day inside a range of days off splits the range.
+	split := NewHolidaySet(
+		Holiday{DateRange: DateRange{StartedOn: Date(2021, 2, 11), EndedOn: Date(2021, 2, 17)}, Name: "春节"},
+		Holiday{DateRange: DateRange{StartedOn: Date(2021, 2, 14), EndedOn: Date(2021, 2, 14)}, Name: "补班", Working: true},
+	)
+	a.Equal(2, len(split.Intervals(time.UTC)))
+	a.Equal(6, len(split.DaysOff()))
+}
+
+func Test_holiday_BusinessCalendar(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	set, err := LoadHolidaysCSV(strings.NewReader(holidayCSV))
+	a.Nil(err)
+
+	calendar := NewBusinessCalendar(loc)
+	calendar.AddHolidaySet(set.Region("CN"), time.Monday)
+
+	a.False(calendar.IsWorkingDay(Date(2021, 10, 1)))
+	a.True(calendar.IsWorkingDay(Date(2021, 9, 26)))
+	a.True(calendar.IsWorkingDay(Date(2021, 10, 9)))
+	a.False(calendar.IsWorkingDay(Date(2021, 10, 10)))
+
+	// Thursday 2021-09-30 17:00 plus 2 working hours skips the National Day week.
+	now, err := calendar.AddWorkingTime(Time(time.Date(2021, 9, 30, 17, 0, 0, 0, loc)), 2*time.Hour)
+	a.Nil(err)
+	a.True(now.Time.Equal(time.Date(2021, 10, 8, 10, 0, 0, 0, loc)))
+
+	// Friday 2021-10-08 17:00 plus 2 working hours lands on the make-up Saturday.
+	now, err = calendar.AddWorkingTime(Time(time.Date(2021, 10, 8, 17, 0, 0, 0, loc)), 2*time.Hour)
+	a.Nil(err)
+	a.True(now.Time.Equal(time.Date(2021, 10, 9, 10, 0, 0, 0, loc)))
+
+	// Make-up days working like a short Friday.
+	calendar = NewBusinessCalendar(loc)
+	calendar.SetWindows(time.Friday, WorkingWindow{From: 9 * time.Hour, To: 15 * time.Hour})
+	calendar.AddHolidaySet(set.Region("CN"), time.Friday)
+	a.Equal(6*time.Hour, calendar.WorkingDuration(Duration{
+		StartedAt: Time(time.Date(2021, 10, 9, 0, 0, 0, 0, loc)),
+		EndedAt:   Time(time.Date(2021, 10, 10, 0, 0, 0, 0, loc)),
+	}))
+}
@@ -0,0 +1,722 @@
+package timestamps
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrInvalidICalendar = errors.New("timestamps: invalid iCalendar data")
+
+const icalDateLayout = "20060102"
+const icalDateTimeLayout = "20060102T150405"
+const icalProductID = "-//hughcube-go//timestamps//EN"
+
+// icalLineOctets is the longest content line before folding, RFC 5545 section 3.1.
+const icalLineOctets = 75
+
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+type icalComponent struct {
+	name       string
+	properties []icalProperty
+	components []icalComponent
+}
+
+func (c *icalComponent) get(name string) (icalProperty, bool) {
+	for _, property := range c.properties {
+		if property.name == name {
+			return property, true
+		}
+	}
+	return icalProperty{}, false
+}
+
+func (c *icalComponent) all(name string) []icalProperty {
+	var properties []icalProperty
+	for _, property := range c.properties {
+		if property.name == name {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+func (c *icalComponent) text(name string) string {
+	if property, ok := c.get(name); ok {
+		return unescapeICalText(property.value)
+	}
+	return ""
+}
+
+// unfoldICalLines joins the continuation lines of RFC 5545 section 3.1.
+func unfoldICalLines(text string) []string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if 0 < len(line) && (line[0] == ' ' || line[0] == '\t') && 0 < len(lines) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if 0 < len(strings.TrimSpace(line)) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func parseICalProperty(line string) (icalProperty, error) {
+	property := icalProperty{params: map[string]string{}}
+
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return property, fmt.Errorf("%w: %q", ErrInvalidICalendar, line)
+	}
+	property.value = line[colon+1:]
+
+	parts := splitICalParams(line[:colon])
+	property.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if index := strings.IndexByte(param, '='); index > 0 {
+			property.params[strings.ToUpper(param[:index])] = strings.Trim(param[index+1:], `"`)
+		}
+	}
+	return property, nil
+}
+
+func splitICalParams(value string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// parseICalComponents returns every component with the given name, wherever it is nested.
+func parseICalComponents(text string, name string) ([]icalComponent, error) {
+	var components []icalComponent
+	var stack []icalComponent
+
+	for _, line := range unfoldICalLines(text) {
+		property, err := parseICalProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch property.name {
+		case "BEGIN":
+			stack = append(stack, icalComponent{name: strings.ToUpper(property.value)})
+		case "END":
+			if 0 >= len(stack) || stack[len(stack)-1].name != strings.ToUpper(property.value) {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidICalendar, line)
+			}
+			component := stack[len(stack)-1]
+			if component.name == name {
+				components = append(components, component)
+			}
+			stack = stack[:len(stack)-1]
+			if 0 < len(stack) {
+				stack[len(stack)-1].components = append(stack[len(stack)-1].components, component)
+			}
+		default:
+			if 0 >= len(stack) {
+				return nil, fmt.Errorf("%w: property outside of a component %q", ErrInvalidICalendar, line)
+			}
+			stack[len(stack)-1].properties = append(stack[len(stack)-1].properties, property)
+		}
+	}
+
+	if 0 < len(stack) {
+		return nil, fmt.Errorf("%w: unterminated %s", ErrInvalidICalendar, stack[len(stack)-1].name)
+	}
+	return components, nil
+}
+
+// parseICalTime reads a DATE or DATE-TIME value, see icalZones for the location of a DATE-TIME without "Z".
+func parseICalTime(property icalProperty, zones icalZones) (time.Time, bool, error) {
+	value := property.value
+
+	if property.params["VALUE"] == "DATE" || len(value) == len(icalDateLayout) {
+		now, err := time.ParseInLocation(icalDateLayout, value, time.UTC)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: %s: %v", ErrInvalidICalendar, property.name, err)
+		}
+		return now, true, nil
+	}
+
+	wall, err := time.ParseInLocation(icalDateTimeLayout, strings.TrimSuffix(value, "Z"), time.UTC)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s: %v", ErrInvalidICalendar, property.name, err)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return wall, false, nil
+	}
+	if tzid, ok := property.params["TZID"]; ok {
+		return zones.at(tzid, wall), false, nil
+	}
+	return icalWallIn(wall, zones.floatingLocation()), false, nil
+}
+
+// icalZones places the DATE-TIME values of a calendar, floating ones in floating (nil means time.Local) and
+// those with a TZID in the zone it names, see ParseICalEvents for the lookup.
+type icalZones struct {
+	floating  *time.Location
+	timezones map[string]icalTimezone
+}
+
+// icalZonesOf collects the VTIMEZONEs of text.
+func icalZonesOf(text string, floating *time.Location) (icalZones, error) {
+	zones := icalZones{floating: floating, timezones: map[string]icalTimezone{}}
+
+	components, err := parseICalComponents(text, "VTIMEZONE")
+	if err != nil {
+		return zones, err
+	}
+	for _, component := range components {
+		if timezone, err := icalTimezoneOf(&component); err != nil {
+			return zones, err
+		} else if 0 < len(timezone.observances) {
+			zones.timezones[component.text("TZID")] = timezone
+		}
+	}
+	return zones, nil
+}
+
+func (z icalZones) floatingLocation() *time.Location {
+	if z.floating == nil {
+		return time.Local
+	}
+	return z.floating
+}
+
+// at places the wall clock of a DATE-TIME with the given TZID.
+func (z icalZones) at(tzid string, wall time.Time) time.Time {
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return icalWallIn(wall, loc)
+	}
+	if timezone, ok := z.timezones[tzid]; ok {
+		return timezone.at(wall)
+	}
+	if name, ok := icalWindowsZones[tzid]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return icalWallIn(wall, loc)
+		}
+	}
+	return icalWallIn(wall, z.floatingLocation())
+}
+
+func icalWallIn(wall time.Time, loc *time.Location) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// icalTimezone is a VTIMEZONE, its STANDARD and DAYLIGHT observances.
+type icalTimezone struct {
+	observances []icalObservance
+}
+
+// icalObservance is a STANDARD or DAYLIGHT component. Only the yearly BYMONTH and BYDAY rules written by
+// Outlook and Exchange repeat it, an observance with any other RRULE starts once at DTSTART.
+type icalObservance struct {
+	name string
+	// start is DTSTART, wall clocks are kept in UTC.
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	month      time.Month
+	week       int
+	weekday    time.Weekday
+	until      time.Time
+}
+
+func icalTimezoneOf(component *icalComponent) (icalTimezone, error) {
+	timezone := icalTimezone{}
+	for _, child := range component.components {
+		if child.name != "STANDARD" && child.name != "DAYLIGHT" {
+			continue
+		}
+
+		observance := icalObservance{name: child.text("TZNAME")}
+		var err error
+		if property, ok := child.get("DTSTART"); !ok {
+			return timezone, fmt.Errorf("%w: %s without DTSTART", ErrInvalidICalendar, child.name)
+		} else if observance.start, _, err = parseICalTime(property, icalZones{floating: time.UTC}); err != nil {
+			return timezone, err
+		}
+		if observance.offsetFrom, err = parseICalOffset(child.text("TZOFFSETFROM")); err != nil {
+			return timezone, err
+		}
+		if observance.offsetTo, err = parseICalOffset(child.text("TZOFFSETTO")); err != nil {
+			return timezone, err
+		}
+		if property, ok := child.get("RRULE"); ok {
+			observance.parseRule(property.value)
+		}
+		timezone.observances = append(timezone.observances, observance)
+	}
+	return timezone, nil
+}
+
+// parseICalOffset reads a UTC-OFFSET such as "-0800" or "+053000" as seconds east of UTC.
+func parseICalOffset(value string) (int, error) {
+	if (len(value) != 5 && len(value) != 7) || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("%w: UTC offset %q", ErrInvalidICalendar, value)
+	}
+
+	offset := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+		if n, err := strconv.Atoi(value[1+2*i : 3+2*i]); err != nil {
+			return 0, fmt.Errorf("%w: UTC offset %q", ErrInvalidICalendar, value)
+		} else {
+			offset += n * unit
+		}
+	}
+	if value[0] == '-' {
+		return -offset, nil
+	}
+	return offset, nil
+}
+
+// parseRule keeps a yearly rule with a single BYMONTH and BYDAY such as "FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10".
+func (o *icalObservance) parseRule(value string) {
+	parts := map[string]string{}
+	for _, part := range strings.Split(strings.ToUpper(value), ";") {
+		if index := strings.IndexByte(part, '='); index > 0 {
+			parts[part[:index]] = part[index+1:]
+		}
+	}
+	if parts["FREQ"] != "YEARLY" || len(parts["BYDAY"]) < 3 {
+		return
+	}
+
+	month, err := strconv.Atoi(parts["BYMONTH"])
+	if err != nil || month < 1 || month > 12 {
+		return
+	}
+	byDay := parts["BYDAY"]
+	week, err := strconv.Atoi(byDay[:len(byDay)-2])
+	if err != nil || week == 0 || week < -5 || week > 5 {
+		return
+	}
+	weekday := strings.Index("SUMOTUWETHFRSA", byDay[len(byDay)-2:])
+	if weekday < 0 || weekday%2 != 0 {
+		return
+	}
+
+	if until, ok := parts["UNTIL"]; ok {
+		if o.until, _, err = parseICalTime(icalProperty{name: "UNTIL", value: until}, icalZones{floating: time.UTC}); err != nil {
+			return
+		}
+	}
+	o.month, o.week, o.weekday = time.Month(month), week, time.Weekday(weekday/2)
+}
+
+// latest returns the last onset of the observance at or before wall.
+func (o *icalObservance) latest(wall time.Time) (time.Time, bool) {
+	if o.month == 0 {
+		return o.start, !o.start.After(wall)
+	}
+
+	last := wall
+	if !o.until.IsZero() && o.until.Before(last) {
+		last = o.until
+	}
+	for year := last.Year(); year >= last.Year()-1 && year >= o.start.Year(); year-- {
+		onset := time.Date(year, o.month, icalWeekdayOf(year, o.month, o.week, o.weekday),
+			o.start.Hour(), o.start.Minute(), o.start.Second(), 0, time.UTC)
+		if !onset.After(last) && !onset.Before(o.start) {
+			return onset, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// icalWeekdayOf returns the day of the week-th weekday of the month, a negative week counting from its end.
+func icalWeekdayOf(year int, month time.Month, week int, weekday time.Weekday) int {
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if week < 0 {
+		last := time.Date(year, month, days, 0, 0, 0, 0, time.UTC)
+		return days - (int(last.Weekday())-int(weekday)+7)%7 + (week+1)*7
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	day := 1 + (int(weekday)-int(first.Weekday())+7)%7 + (week-1)*7
+	for day > days {
+		day -= 7
+	}
+	return day
+}
+
+// at places a wall clock by the observance with the latest onset, before every onset the zone keeps the
+// TZOFFSETFROM of the earliest observance.
+func (z icalTimezone) at(wall time.Time) time.Time {
+	var current *icalObservance
+	var onset time.Time
+	for i := range z.observances {
+		if start, ok := z.observances[i].latest(wall); ok && (current == nil || start.After(onset)) {
+			current, onset = &z.observances[i], start
+		}
+	}
+
+	name, offset := "", 0
+	if current != nil {
+		name, offset = current.name, current.offsetTo
+	} else {
+		earliest := &z.observances[0]
+		for i := range z.observances {
+			if z.observances[i].start.Before(earliest.start) {
+				earliest = &z.observances[i]
+			}
+		}
+		offset = earliest.offsetFrom
+	}
+	return icalWallIn(wall, time.FixedZone(name, offset))
+}
+
+// icalWindowsZones maps the Windows zone names used as TZID by Outlook and Exchange to IANA names, after
+// the territory "001" entries of CLDR windowsZones.xml.
+var icalWindowsZones = map[string]string{
+	"Dateline Standard Time":          "Etc/GMT+12",
+	"UTC-11":                          "Etc/GMT+11",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"Pacific Standard Time (Mexico)":  "America/Tijuana",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Mountain Standard Time (Mexico)": "America/Mazatlan",
+	"Mountain Standard Time":          "America/Denver",
+	"Central America Standard Time":   "America/Guatemala",
+	"Central Standard Time":           "America/Chicago",
+	"Central Standard Time (Mexico)":  "America/Mexico_City",
+	"Canada Central Standard Time":    "America/Regina",
+	"SA Pacific Standard Time":        "America/Bogota",
+	"Eastern Standard Time":           "America/New_York",
+	"Eastern Standard Time (Mexico)":  "America/Cancun",
+	"US Eastern Standard Time":        "America/Indiana/Indianapolis",
+	"Venezuela Standard Time":         "America/Caracas",
+	"Atlantic Standard Time":          "America/Halifax",
+	"SA Western Standard Time":        "America/La_Paz",
+	"Pacific SA Standard Time":        "America/Santiago",
+	"Newfoundland Standard Time":      "America/St_Johns",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"Argentina Standard Time":         "America/Argentina/Buenos_Aires",
+	"SA Eastern Standard Time":        "America/Cayenne",
+	"UTC-02":                          "Etc/GMT+2",
+	"Azores Standard Time":            "Atlantic/Azores",
+	"Cape Verde Standard Time":        "Atlantic/Cape_Verde",
+	"GMT Standard Time":               "Europe/London",
+	"Greenwich Standard Time":         "Atlantic/Reykjavik",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Romance Standard Time":           "Europe/Paris",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"Middle East Standard Time":       "Asia/Beirut",
+	"Egypt Standard Time":             "Africa/Cairo",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"FLE Standard Time":               "Europe/Kiev",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"E. Europe Standard Time":         "Europe/Chisinau",
+	"Turkey Standard Time":            "Europe/Istanbul",
+	"Arab Standard Time":              "Asia/Riyadh",
+	"Arabic Standard Time":            "Asia/Baghdad",
+	"E. Africa Standard Time":         "Africa/Nairobi",
+	"Russian Standard Time":           "Europe/Moscow",
+	"Iran Standard Time":              "Asia/Tehran",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"Afghanistan Standard Time":       "Asia/Kabul",
+	"Pakistan Standard Time":          "Asia/Karachi",
+	"West Asia Standard Time":         "Asia/Tashkent",
+	"India Standard Time":             "Asia/Kolkata",
+	"Sri Lanka Standard Time":         "Asia/Colombo",
+	"Nepal Standard Time":             "Asia/Kathmandu",
+	"Central Asia Standard Time":      "Asia/Almaty",
+	"Bangladesh Standard Time":        "Asia/Dhaka",
+	"Myanmar Standard Time":           "Asia/Yangon",
+	"SE Asia Standard Time":           "Asia/Bangkok",
+	"China Standard Time":             "Asia/Shanghai",
+	"North Asia East Standard Time":   "Asia/Irkutsk",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"W. Australia Standard Time":      "Australia/Perth",
+	"Taipei Standard Time":            "Asia/Taipei",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"Korea Standard Time":             "Asia/Seoul",
+	"Cen. Australia Standard Time":    "Australia/Adelaide",
+	"AUS Central Standard Time":       "Australia/Darwin",
+	"E. Australia Standard Time":      "Australia/Brisbane",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"West Pacific Standard Time":      "Pacific/Port_Moresby",
+	"Tasmania Standard Time":          "Australia/Hobart",
+	"Vladivostok Standard Time":       "Asia/Vladivostok",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+	"UTC+12":                          "Etc/GMT-12",
+	"Fiji Standard Time":              "Pacific/Fiji",
+	"Tonga Standard Time":             "Pacific/Tongatapu",
+	"Samoa Standard Time":             "Pacific/Apia",
+}
+
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// icalEventSpan returns DTSTART and DTEND (or DTSTART plus DURATION) of an event, all-day dates are UTC midnights.
+func icalEventSpan(event *icalComponent, zones icalZones) (time.Time, time.Time, bool, error) {
+	property, ok := event.get("DTSTART")
+	if !ok {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w: VEVENT without DTSTART", ErrInvalidICalendar)
+	}
+	start, allDay, err := parseICalTime(property, zones)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+
+	end := start
+	if property, ok := event.get("DTEND"); ok {
+		if end, _, err = parseICalTime(property, zones); err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+	} else if property, ok := event.get("DURATION"); ok {
+		if period, err := parseISOPeriod(property.value); err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("%w: DURATION: %v", ErrInvalidICalendar, err)
+		} else {
+			end = period.addTo(start)
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	return start, end, allDay, nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// ICalEvent is a Duration with the VEVENT properties needed for calendar invites.
+type ICalEvent struct {
+	Duration
+	// UID defaults to a random one, set it to keep the event identity across exports.
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	// AllDay exports the dates touched by the Duration as VALUE=DATE, DTEND being the day after the last date.
+	AllDay bool
+	// Stamp is DTSTAMP, the current time when invalid.
+	Stamp sql.NullTime
+}
+
+// ParseICalEvents reads every VEVENT, floating times and all-day dates are placed in loc (nil means time.Local).
+// A TZID is looked up as an IANA name, then in the VTIMEZONEs of text, then as a Windows zone name such as
+// "Pacific Standard Time", and falls back to loc when none of them knows it.
+func ParseICalEvents(text string, loc *time.Location) ([]ICalEvent, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	components, err := parseICalComponents(text, "VEVENT")
+	if err != nil {
+		return nil, err
+	}
+	zones, err := icalZonesOf(text, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ICalEvent, 0, len(components))
+	for _, component := range components {
+		if event, err := icalEventOf(&component, zones); err != nil {
+			return nil, err
+		} else {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func icalEventOf(component *icalComponent, zones icalZones) (ICalEvent, error) {
+	start, end, allDay, err := icalEventSpan(component, zones)
+	if err != nil {
+		return ICalEvent{}, err
+	}
+
+	event := ICalEvent{
+		UID:         component.text("UID"),
+		Summary:     component.text("SUMMARY"),
+		Description: component.text("DESCRIPTION"),
+		Location:    component.text("LOCATION"),
+		AllDay:      allDay,
+	}
+	if allDay {
+		event.StartedAt = DateOf(start).In(zones.floatingLocation())
+		event.EndedAt = DateOf(end).In(zones.floatingLocation())
+	} else {
+		event.StartedAt = validTime(start)
+		event.EndedAt = validTime(end)
+	}
+	if property, ok := component.get("DTSTAMP"); ok {
+		if stamp, _, err := parseICalTime(property, icalZones{floating: time.UTC}); err == nil {
+			event.Stamp = validTime(stamp)
+		}
+	}
+	return event, nil
+}
+
+// FormatICalEvent writes a VEVENT with times in UTC to the second, so that it needs no VTIMEZONE. loc (nil means
+// time.Local) only decides the dates of an all-day event. An event without StartedAt yields "".
+func FormatICalEvent(event ICalEvent, loc *time.Location) string {
+	if !event.StartedAt.Valid {
+		return ""
+	}
+
+	b := &strings.Builder{}
+	writeICalEvent(b, event, loc)
+	return b.String()
+}
+
+// FormatICalendar wraps the events into a VCALENDAR, events without StartedAt are left out.
+func FormatICalendar(events []ICalEvent, loc *time.Location) string {
+	b := &strings.Builder{}
+	writeICalLine(b, "BEGIN:VCALENDAR")
+	writeICalLine(b, "VERSION:2.0")
+	writeICalLine(b, "PRODID:"+icalProductID)
+	writeICalLine(b, "CALSCALE:GREGORIAN")
+	for _, event := range events {
+		if event.StartedAt.Valid {
+			writeICalEvent(b, event, loc)
+		}
+	}
+	writeICalLine(b, "END:VCALENDAR")
+	return b.String()
+}
+
+func writeICalEvent(b *strings.Builder, event ICalEvent, loc *time.Location) {
+	stamp := time.Now()
+	if event.Stamp.Valid {
+		stamp = event.Stamp.Time
+	}
+	uid := event.UID
+	if 0 >= len(uid) {
+		uid = newICalUID()
+	}
+
+	writeICalLine(b, "BEGIN:VEVENT")
+	writeICalLine(b, "UID:"+escapeICalText(uid))
+	writeICalLine(b, "DTSTAMP:"+formatICalTime(stamp))
+
+	if event.AllDay {
+		dates := DateRangeOf(event.Duration, loc)
+		if !dates.EndedOn.Valid || dates.EndedOn.Before(dates.StartedOn) {
+			dates.EndedOn = dates.StartedOn
+		}
+		writeICalLine(b, "DTSTART;VALUE=DATE:"+dates.StartedOn.utc().Format(icalDateLayout))
+		writeICalLine(b, "DTEND;VALUE=DATE:"+dates.EndedOn.AddDays(1).utc().Format(icalDateLayout))
+	} else {
+		writeICalLine(b, "DTSTART:"+formatICalTime(event.StartedAt.Time))
+		if event.EndedAt.Valid {
+			writeICalLine(b, "DTEND:"+formatICalTime(event.EndedAt.Time))
+		}
+	}
+
+	if 0 < len(event.Summary) {
+		writeICalLine(b, "SUMMARY:"+escapeICalText(event.Summary))
+	}
+	if 0 < len(event.Description) {
+		writeICalLine(b, "DESCRIPTION:"+escapeICalText(event.Description))
+	}
+	if 0 < len(event.Location) {
+		writeICalLine(b, "LOCATION:"+escapeICalText(event.Location))
+	}
+	writeICalLine(b, "END:VEVENT")
+}
+
+// formatICalTime returns a UTC DATE-TIME value, a TZID parameter would need a matching VTIMEZONE (RFC 5545 section 3.2.19).
+func formatICalTime(now time.Time) string {
+	return now.UTC().Format(icalDateTimeLayout) + "Z"
+}
+
+// newICalUID returns a random version 4 UUID, falling back to the current time if there is no randomness.
+func newICalUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x@timestamps", time.Now().UnixNano())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x@timestamps", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func escapeICalText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`).Replace(value)
+}
+
+// writeICalLine folds the line at icalLineOctets without splitting a UTF-8 sequence and ends it with CRLF.
+func writeICalLine(b *strings.Builder, line string) {
+	limit := icalLineOctets
+	for limit < len(line) {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// SetFromICalEvent reads the first VEVENT of text, see ParseICalEvents for loc.
+func (t *Duration) SetFromICalEvent(text string, loc *time.Location) error {
+	events, err := ParseICalEvents(text, loc)
+	if err != nil {
+		return err
+	}
+	if 0 >= len(events) {
+		return fmt.Errorf("%w: no VEVENT", ErrInvalidICalendar)
+	}
+
+	t.StartedAt = events[0].StartedAt
+	t.EndedAt = events[0].EndedAt
+	return nil
+}
+
+func (t *Duration) GetICalEvent(summary string, loc *time.Location) string {
+	return FormatICalEvent(ICalEvent{Duration: *t, Summary: summary}, loc)
+}
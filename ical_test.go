@@ -0,0 +1,219 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ical_FormatICalEvent(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	event := ICalEvent{
+		Duration: Duration{
+			StartedAt: Time(time.Date(2021, 3, 14, 1, 30, 0, 0, loc)),
+			EndedAt:   Time(time.Date(2021, 3, 14, 3, 30, 0, 0, loc)),
+		},
+		UID:     "maintenance-1",
+		Summary: "Maintenance; db, cache",
+		Stamp:   Time(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	a.Equal("BEGIN:VEVENT\r\n"+
+		"UID:maintenance-1\r\n"+
+		"DTSTAMP:20210301T000000Z\r\n"+
+		"DTSTART:20210314T063000Z\r\n"+
+		"DTEND:20210314T073000Z\r\n"+
+		"SUMMARY:Maintenance\\; db\\, cache\r\n"+
+		"END:VEVENT\r\n", FormatICalEvent(event, loc))
+
+	a.Contains(FormatICalEvent(event, nil), "DTSTART:20210314T063000Z\r\n")
+	a.Contains(FormatICalEvent(event, time.Local), "DTEND:20210314T073000Z\r\n")
+	a.Equal("", FormatICalEvent(ICalEvent{}, nil))
+
+	// Events without UID get unique ones even when they start at the same time.
+	event.UID = ""
+	first, second := FormatICalEvent(event, loc), FormatICalEvent(event, loc)
+	a.Regexp("UID:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}@timestamps\r\n", first)
+	a.NotEqual(first, second)
+
+	event.AllDay = true
+	event.EndedAt = Time(time.Date(2021, 3, 16, 0, 0, 0, 0, loc))
+	exported := FormatICalEvent(event, loc)
+	a.Contains(exported, "DTSTART;VALUE=DATE:20210314\r\n")
+	a.Contains(exported, "DTEND;VALUE=DATE:20210316\r\n")
+
+	// Long lines are folded at 75 octets without splitting characters.
+	event.Description = strings.Repeat("维护窗口", 20)
+	for _, line := range strings.Split(FormatICalEvent(event, loc), "\r\n") {
+		a.True(len(line) <= 75)
+	}
+
+	calendar := FormatICalendar([]ICalEvent{event, {}}, loc)
+	a.True(strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	a.Equal(1, strings.Count(calendar, "BEGIN:VEVENT"))
+	a.NotContains(calendar, "TZID=")
+}
+
+func Test_ical_ParseICalEvents(t *testing.T) {
+	a := assert.New(t)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+	newYork, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	text := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:a\r\n" +
+		"DTSTART;TZID=America/New_York:20211107T003000\r\n" +
+		"DTEND:20211107T070000Z\r\n" +
+		"SUMMARY:Line one\\nline two\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:b\r\n" +
+		"DTSTART;VALUE=DATE:20211001\r\n" +
+		"DTEND;VALUE=DATE:20211008\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:c\r\n" +
+		"DTSTART:20211001T090000\r\n" +
+		"DURATION:PT1H30M\r\n" +
+		"LOCATION:Room 1\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := ParseICalEvents(text, shanghai)
+	a.Nil(err)
+	a.Equal(3, len(events))
+
+	a.Equal("Line one\nline two", events[0].Summary)
+	a.True(events[0].StartedAt.Time.Equal(time.Date(2021, 11, 7, 0, 30, 0, 0, newYork)))
+	a.Equal(int64(2*time.Hour+30*time.Minute), events[0].GetDurationLength())
+	a.False(events[0].AllDay)
+
+	a.True(events[1].AllDay)
+	a.True(events[1].StartedAt.Time.Equal(time.Date(2021, 10, 1, 0, 0, 0, 0, shanghai)))
+	a.True(events[1].EndedAt.Time.Equal(time.Date(2021, 10, 8, 0, 0, 0, 0, shanghai)))
+
+	a.Equal("Room 1", events[2].Location)
+	a.True(events[2].StartedAt.Time.Equal(time.Date(2021, 10, 1, 9, 0, 0, 0, shanghai)))
+	a.True(events[2].EndedAt.Time.Equal(time.Date(2021, 10, 1, 10, 30, 0, 0, shanghai)))
+
+	events, err = ParseICalEvents("BEGIN:VEVENT\r\nDTSTART;TZID=Nowhere/City:20211001T090000\r\nEND:VEVENT\r\n", shanghai)
+	a.Nil(err)
+	a.True(events[0].StartedAt.Time.Equal(time.Date(2021, 10, 1, 9, 0, 0, 0, shanghai)))
+	_, err = ParseICalEvents("DTSTART:20211001T090000\r\n", nil)
+	a.True(errors.Is(err, ErrInvalidICalendar))
+}
+
+func Test_ical_ParseICalEvents_Outlook(t *testing.T) {
+	a := assert.New(t)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	a.Nil(err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	a.Nil(err)
+
+	text := "BEGIN:VCALENDAR\r\n" +
+		"PRODID:-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Pacific Standard Time\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:16011104T020000\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11\r\n" +
+		"TZOFFSETFROM:-0700\r\n" +
+		"TZOFFSETTO:-0800\r\n" +
+		"END:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:16010311T020000\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3\r\n" +
+		"TZOFFSETFROM:-0800\r\n" +
+		"TZOFFSETTO:-0700\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Customized Time Zone\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:16010101T000000\r\n" +
+		"TZOFFSETFROM:+0530\r\n" +
+		"TZOFFSETTO:+0530\r\n" +
+		"END:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:summer\r\n" +
+		"DTSTART;TZID=Pacific Standard Time:20210715T090000\r\n" +
+		"DTEND;TZID=Pacific Standard Time:20210715T100000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:winter\r\n" +
+		"DTSTART;TZID=\"Pacific Standard Time\":20211215T090000\r\n" +
+		"DTEND;TZID=\"Pacific Standard Time\":20211215T100000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:custom\r\n" +
+		"DTSTART;TZID=Customized Time Zone:20211001T090000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:windows\r\n" +
+		"DTSTART;TZID=Tokyo Standard Time:20211001T090000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:unknown\r\n" +
+		"DTSTART;TZID=Somewhere Standard Time:20211001T090000\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := ParseICalEvents(text, shanghai)
+	a.Nil(err)
+	a.Equal(5, len(events))
+
+	a.True(events[0].StartedAt.Time.Equal(time.Date(2021, 7, 15, 9, 0, 0, 0, losAngeles)))
+	a.True(events[0].EndedAt.Time.Equal(time.Date(2021, 7, 15, 10, 0, 0, 0, losAngeles)))
+	a.True(events[1].StartedAt.Time.Equal(time.Date(2021, 12, 15, 9, 0, 0, 0, losAngeles)))
+	a.True(events[2].StartedAt.Time.Equal(time.Date(2021, 10, 1, 3, 30, 0, 0, time.UTC)))
+	a.True(events[3].StartedAt.Time.Equal(time.Date(2021, 10, 1, 9, 0, 0, 0, tokyo)))
+	a.True(events[4].StartedAt.Time.Equal(time.Date(2021, 10, 1, 9, 0, 0, 0, shanghai)))
+
+	for _, now := range []time.Time{
+		time.Date(2021, 3, 14, 1, 59, 0, 0, losAngeles),
+		time.Date(2021, 3, 14, 3, 0, 0, 0, losAngeles),
+		time.Date(2021, 11, 7, 2, 0, 0, 0, losAngeles),
+		time.Date(2021, 1, 1, 0, 0, 0, 0, losAngeles),
+	} {
+		events, err := ParseICalEvents(strings.Replace(text, "20210715T090000", now.Format(icalDateTimeLayout), 1), nil)
+		a.Nil(err)
+		a.True(events[0].StartedAt.Time.Equal(now), now.String())
+	}
+}
+
+func Test_ical_Duration(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	duration := Duration{
+		StartedAt: Time(time.Date(2021, 10, 1, 9, 0, 0, 0, loc)),
+		EndedAt:   Time(time.Date(2021, 10, 1, 18, 0, 0, 0, loc)),
+	}
+
+	exported := duration.GetICalEvent("Review", loc)
+	a.Contains(exported, "SUMMARY:Review\r\n")
+
+	imported := Duration{}
+	a.Nil(imported.SetFromICalEvent(FormatICalendar([]ICalEvent{{Duration: duration}}, loc), nil))
+	a.True(imported.StartedAt.Time.Equal(duration.StartedAt.Time))
+	a.True(imported.EndedAt.Time.Equal(duration.EndedAt.Time))
+	a.Equal("UTC", imported.StartedAt.Time.Location().String())
+
+	a.True(errors.Is(imported.SetFromICalEvent("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", nil), ErrInvalidICalendar))
+}
@@ -0,0 +1,110 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidISO8601Interval = errors.New("timestamps: invalid ISO 8601 interval")
+
+const isoIntervalOpen = ".."
+
+var isoInstantLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func ParseInterval(value string) (Duration, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || (0 >= len(parts[0]) && 0 >= len(parts[1])) {
+		return Duration{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Interval, value)
+	}
+
+	startIsPeriod := strings.HasPrefix(parts[0], "P") || strings.HasPrefix(parts[0], "-P")
+	endIsPeriod := strings.HasPrefix(parts[1], "P") || strings.HasPrefix(parts[1], "-P")
+	if startIsPeriod && endIsPeriod {
+		return Duration{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Interval, value)
+	}
+
+	duration := Duration{}
+	var err error
+
+	if !startIsPeriod {
+		if duration.StartedAt, err = parseISOIntervalInstant(parts[0]); err != nil {
+			return Duration{}, fmt.Errorf("%w: %q: %v", ErrInvalidISO8601Interval, value, err)
+		}
+	}
+
+	if !endIsPeriod {
+		if duration.EndedAt, err = parseISOIntervalInstant(parts[1]); err != nil {
+			return Duration{}, fmt.Errorf("%w: %q: %v", ErrInvalidISO8601Interval, value, err)
+		}
+	}
+
+	if startIsPeriod {
+		period, err := parseISOPeriod(parts[0])
+		if err != nil || !duration.EndedAt.Valid {
+			return Duration{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Interval, value)
+		}
+		duration.StartedAt = validTime(period.subFrom(duration.EndedAt.Time))
+	}
+
+	if endIsPeriod {
+		period, err := parseISOPeriod(parts[1])
+		if err != nil || !duration.StartedAt.Valid {
+			return Duration{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Interval, value)
+		}
+		duration.EndedAt = validTime(period.addTo(duration.StartedAt.Time))
+	}
+
+	return duration, nil
+}
+
+func parseISOIntervalInstant(value string) (sql.NullTime, error) {
+	if 0 >= len(value) || value == isoIntervalOpen {
+		return NilTime(), nil
+	}
+
+	var err error
+	for _, layout := range isoInstantLayouts {
+		var now sql.NullTime
+		if now, err = ParseWithLayout(layout, value); err == nil {
+			return now, nil
+		}
+	}
+	return NilTime(), err
+}
+
+func FormatInterval(duration Duration) string {
+	return formatISOIntervalInstant(duration.StartedAt) + "/" + formatISOIntervalInstant(duration.EndedAt)
+}
+
+func formatISOIntervalInstant(t sql.NullTime) string {
+	if !t.Valid {
+		return isoIntervalOpen
+	}
+	return FormatRFC3339Nano(t)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t *Duration) SetFromISOInterval(value string) error {
+	if duration, err := ParseInterval(value); err != nil {
+		return err
+	} else {
+		t.StartedAt = duration.StartedAt
+		t.EndedAt = duration.EndedAt
+		return nil
+	}
+}
+
+func (t *Duration) GetISOInterval() string {
+	return FormatInterval(*t)
+}
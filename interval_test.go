@@ -0,0 +1,53 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_interval_ParseInterval(t *testing.T) {
+	a := assert.New(t)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	duration, err := ParseInterval("2021-01-01T00:00:00Z/2021-02-01T00:00:00Z")
+	a.Nil(err)
+	a.True(duration.StartedAt.Valid && duration.StartedAt.Time.Equal(start))
+	a.True(duration.EndedAt.Valid && duration.EndedAt.Time.Equal(end))
+
+	duration, err = ParseInterval("2021-01-01T00:00:00Z/P1M")
+	a.Nil(err)
+	a.True(duration.EndedAt.Time.Equal(end))
+
+	duration, err = ParseInterval("P1D/2021-01-02T00:00:00Z")
+	a.Nil(err)
+	a.True(duration.StartedAt.Time.Equal(start))
+
+	duration, err = ParseInterval("../2021-01-01T00:00:00Z")
+	a.Nil(err)
+	a.False(duration.StartedAt.Valid)
+	a.True(duration.EndedAt.Valid)
+
+	duration, err = ParseInterval("2021-01-01/..")
+	a.Nil(err)
+	a.True(duration.StartedAt.Valid)
+	a.False(duration.EndedAt.Valid)
+
+	for _, value := range []string{"", "/", "P1D/P2D", "../P1D", "2021-01-01", "foo/bar"} {
+		_, err = ParseInterval(value)
+		a.NotNil(err, value)
+	}
+}
+
+func Test_interval_FormatInterval(t *testing.T) {
+	a := assert.New(t)
+
+	duration := Duration{}
+	a.Nil(duration.SetFromISOInterval("2021-01-01T00:00:00Z/PT1H30M"))
+	a.Equal("2021-01-01T00:00:00Z/2021-01-01T01:30:00Z", duration.GetISOInterval())
+
+	duration.EndedAt = NilTime()
+	a.Equal("2021-01-01T00:00:00Z/..", duration.GetISOInterval())
+}
@@ -0,0 +1,691 @@
+package timestamps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUntranslatableLayout = errors.New("timestamps: untranslatable layout")
+
+// Layouts passed to ParseWithLayout, FormatWithLayout and the *WithLayout accessors may be given in a
+// foreign syntax by prefixing them, e.g. "php:Y-m-d H:i:s", "strftime:%Y-%m-%d" or "java:yyyy-MM-dd".
+const PHPLayoutPrefix = "php:"
+const StrftimeLayoutPrefix = "strftime:"
+const JavaLayoutPrefix = "java:"
+const MomentLayoutPrefix = "moment:"
+
+func ResolveLayout(layout string) (string, error) {
+	switch {
+	case strings.HasPrefix(layout, PHPLayoutPrefix):
+		return LayoutFromPHP(strings.TrimPrefix(layout, PHPLayoutPrefix))
+	case strings.HasPrefix(layout, StrftimeLayoutPrefix):
+		return LayoutFromStrftime(strings.TrimPrefix(layout, StrftimeLayoutPrefix))
+	case strings.HasPrefix(layout, JavaLayoutPrefix):
+		return LayoutFromJava(strings.TrimPrefix(layout, JavaLayoutPrefix))
+	case strings.HasPrefix(layout, MomentLayoutPrefix):
+		return LayoutFromMoment(strings.TrimPrefix(layout, MomentLayoutPrefix))
+	}
+	return layout, nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+const (
+	goLongMonth      = "January"
+	goMonth          = "Jan"
+	goNumMonth       = "1"
+	goZeroMonth      = "01"
+	goLongWeekDay    = "Monday"
+	goWeekDay        = "Mon"
+	goDay            = "2"
+	goUnderDay       = "_2"
+	goZeroDay        = "02"
+	goHour           = "15"
+	goHour12         = "3"
+	goZeroHour12     = "03"
+	goMinute         = "4"
+	goZeroMinute     = "04"
+	goSecond         = "5"
+	goZeroSecond     = "05"
+	goLongYear       = "2006"
+	goYear           = "06"
+	goPM             = "PM"
+	gopm             = "pm"
+	goTZ             = "MST"
+	goISO8601TZ      = "Z0700"
+	goISO8601ColonTZ = "Z07:00"
+	goISO8601ShortTZ = "Z07"
+	goNumTZ          = "-0700"
+	goNumColonTZ     = "-07:00"
+	goNumShortTZ     = "-07"
+)
+
+// layoutBuilder assembles a Go layout, making sure literal text cannot be mistaken for a layout element.
+// The elements are only checked once the layout is complete: literals come in a character at a time, and a literal
+// or chunk can run into its neighbours, like "Mon" from "M", "o", "n" or the hour "15" from the month "1" and second "5".
+type layoutBuilder struct {
+	builder strings.Builder
+	source  string
+	chunks  []layoutChunk
+	err     error
+}
+
+// layoutChunk is a layout element and its offset in the layout.
+type layoutChunk struct {
+	offset int
+	chunk  string
+}
+
+func (b *layoutBuilder) chunk(chunk string) {
+	b.expect(b.builder.Len(), chunk)
+	b.builder.WriteString(chunk)
+}
+
+func (b *layoutBuilder) literal(literal string) {
+	b.builder.WriteString(literal)
+}
+
+// fraction appends n fractional second digits, Go only recognises them right after a '.' or ','.
+func (b *layoutBuilder) fraction(n int) {
+	current := b.builder.String()
+	if 0 >= len(current) || (current[len(current)-1] != '.' && current[len(current)-1] != ',') {
+		b.fail("fractional seconds without a preceding separator")
+		return
+	}
+	b.expect(len(current)-1, current[len(current)-1:]+strings.Repeat("0", n))
+	b.builder.WriteString(strings.Repeat("0", n))
+}
+
+// expect records the elements Go finds in layout on its own, as layout is written at offset.
+func (b *layoutBuilder) expect(offset int, layout string) {
+	for rest := layout; 0 < len(rest); {
+		prefix, chunk, suffix := nextGoLayoutChunk(rest)
+		if 0 < len(chunk) {
+			b.chunks = append(b.chunks, layoutChunk{offset: offset + len(layout) - len(rest) + len(prefix), chunk: chunk})
+		}
+		rest = suffix
+	}
+}
+
+func (b *layoutBuilder) fail(reason string) {
+	if b.err == nil {
+		b.err = fmt.Errorf("%w: %q: %s", ErrUntranslatableLayout, b.source, reason)
+	}
+}
+
+// result fails unless Go finds exactly the chunks that were written in the whole layout.
+func (b *layoutBuilder) result() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	layout := b.builder.String()
+	found := layoutBuilder{}
+	found.expect(0, layout)
+	for i, chunk := range found.chunks {
+		if i >= len(b.chunks) || chunk != b.chunks[i] {
+			b.fail(fmt.Sprintf("Go reads %q at offset %d", chunk.chunk, chunk.offset))
+			return "", b.err
+		}
+	}
+	if len(found.chunks) < len(b.chunks) {
+		chunk := b.chunks[len(found.chunks)]
+		b.fail(fmt.Sprintf("%q at offset %d runs into the text around it", chunk.chunk, chunk.offset))
+		return "", b.err
+	}
+	return layout, nil
+}
+
+func nextGoLayoutChunk(layout string) (prefix string, chunk string, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		rest := layout[i:]
+		switch layout[i] {
+		case 'J':
+			if strings.HasPrefix(rest, goLongMonth) {
+				return layout[:i], goLongMonth, layout[i+7:]
+			}
+			if strings.HasPrefix(rest, goMonth) && !startsWithLowerCase(layout[i+3:]) {
+				return layout[:i], goMonth, layout[i+3:]
+			}
+		case 'M':
+			if strings.HasPrefix(rest, goLongWeekDay) {
+				return layout[:i], goLongWeekDay, layout[i+6:]
+			}
+			if strings.HasPrefix(rest, goWeekDay) && !startsWithLowerCase(layout[i+3:]) {
+				return layout[:i], goWeekDay, layout[i+3:]
+			}
+			if strings.HasPrefix(rest, goTZ) {
+				return layout[:i], goTZ, layout[i+3:]
+			}
+		case '0':
+			if len(rest) >= 2 && '1' <= rest[1] && rest[1] <= '6' {
+				return layout[:i], rest[:2], layout[i+2:]
+			}
+		case '1':
+			if strings.HasPrefix(rest, goHour) {
+				return layout[:i], goHour, layout[i+2:]
+			}
+			return layout[:i], goNumMonth, layout[i+1:]
+		case '2':
+			if strings.HasPrefix(rest, goLongYear) {
+				return layout[:i], goLongYear, layout[i+4:]
+			}
+			return layout[:i], goDay, layout[i+1:]
+		case '_':
+			if strings.HasPrefix(rest, "_2") {
+				if strings.HasPrefix(rest, "_2006") {
+					return layout[:i+1], goLongYear, layout[i+5:]
+				}
+				return layout[:i], goUnderDay, layout[i+2:]
+			}
+		case '3', '4', '5':
+			return layout[:i], rest[:1], layout[i+1:]
+		case 'P':
+			if strings.HasPrefix(rest, goPM) {
+				return layout[:i], goPM, layout[i+2:]
+			}
+		case 'p':
+			if strings.HasPrefix(rest, gopm) {
+				return layout[:i], gopm, layout[i+2:]
+			}
+		case '-', 'Z':
+			for _, zone := range []string{"-07:00:00", "-070000", "Z07:00:00", "Z070000", goNumColonTZ, goNumTZ, goNumShortTZ, goISO8601ColonTZ, goISO8601TZ, goISO8601ShortTZ} {
+				if strings.HasPrefix(rest, zone) {
+					return layout[:i], zone, layout[i+len(zone):]
+				}
+			}
+		case '.', ',':
+			if len(rest) >= 2 && (rest[1] == '0' || rest[1] == '9') {
+				j := 1
+				for j < len(rest) && rest[j] == rest[1] {
+					j++
+				}
+				if j >= len(rest) || rest[j] < '0' || '9' < rest[j] {
+					return layout[:i], rest[:j], layout[i+j:]
+				}
+			}
+		}
+	}
+	return layout, "", ""
+}
+
+func isGoLayoutChunk(layout string) bool {
+	prefix, chunk, suffix := nextGoLayoutChunk(layout)
+	return 0 >= len(prefix) && 0 >= len(suffix) && chunk == layout
+}
+
+func startsWithLowerCase(s string) bool {
+	return 0 < len(s) && 'a' <= s[0] && s[0] <= 'z'
+}
+
+func isLayoutLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// translateFromGo walks a Go layout and hands every element to the dialect, literals are escaped by the dialect.
+func translateFromGo(layout string, dialect string, chunks map[string]string, fraction func(n int) string, escape func(literal string) string) (string, error) {
+	var builder strings.Builder
+	for rest := layout; 0 < len(rest); {
+		prefix, chunk, suffix := nextGoLayoutChunk(rest)
+		if 0 < len(prefix) {
+			builder.WriteString(escape(prefix))
+		}
+		rest = suffix
+
+		if 0 >= len(chunk) {
+			continue
+		}
+
+		if chunk[0] == '.' || chunk[0] == ',' {
+			if token := fraction(len(chunk) - 1); chunk[1] == '0' && 0 < len(token) {
+				builder.WriteString(escape(chunk[:1]) + token)
+				continue
+			}
+		} else if token, ok := chunks[chunk]; ok {
+			builder.WriteString(token)
+			continue
+		}
+
+		return "", fmt.Errorf("%w: %q has no %s equivalent for %q", ErrUntranslatableLayout, layout, dialect, chunk)
+	}
+	return builder.String(), nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var phpLayoutTokens = map[byte]string{
+	'd': goZeroDay,
+	'D': goWeekDay,
+	'j': goDay,
+	'l': goLongWeekDay,
+	'F': goLongMonth,
+	'm': goZeroMonth,
+	'M': goMonth,
+	'n': goNumMonth,
+	'Y': goLongYear,
+	'y': goYear,
+	'a': gopm,
+	'A': goPM,
+	'g': goHour12,
+	'h': goZeroHour12,
+	'H': goHour,
+	'i': goZeroMinute,
+	's': goZeroSecond,
+	'T': goTZ,
+	'O': goNumTZ,
+	'P': goNumColonTZ,
+	'p': goISO8601ColonTZ,
+	'c': "2006-01-02T15:04:05-07:00",
+	'r': "Mon, 02 Jan 2006 15:04:05 -0700",
+}
+
+var phpUntranslatableTokens = "NSwzWtLoBGeIZUx"
+
+func LayoutFromPHP(format string) (string, error) {
+	b := layoutBuilder{source: format}
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '\\':
+			if i+1 < len(format) {
+				i++
+				b.literal(format[i : i+1])
+			}
+		case c == 'u':
+			b.fraction(6)
+		case c == 'v':
+			b.fraction(3)
+		case strings.IndexByte(phpUntranslatableTokens, c) >= 0:
+			b.fail(fmt.Sprintf("token %q", c))
+		default:
+			if chunk, ok := phpLayoutTokens[c]; ok {
+				b.chunk(chunk)
+			} else {
+				b.literal(format[i : i+1])
+			}
+		}
+	}
+	return b.result()
+}
+
+func LayoutToPHP(layout string) (string, error) {
+	chunks := map[string]string{}
+	for token, chunk := range phpLayoutTokens {
+		if isGoLayoutChunk(chunk) {
+			chunks[chunk] = string(token)
+		}
+	}
+
+	return translateFromGo(layout, "PHP", chunks, func(n int) string {
+		switch n {
+		case 3:
+			return "v"
+		case 6:
+			return "u"
+		}
+		return ""
+	}, func(literal string) string {
+		var builder strings.Builder
+		for i := 0; i < len(literal); i++ {
+			if isLayoutLetter(literal[i]) || literal[i] == '\\' {
+				builder.WriteByte('\\')
+			}
+			builder.WriteByte(literal[i])
+		}
+		return builder.String()
+	})
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var strftimeLayoutTokens = map[byte]string{
+	'Y': goLongYear,
+	'y': goYear,
+	'm': goZeroMonth,
+	'b': goMonth,
+	'B': goLongMonth,
+	'd': goZeroDay,
+	'e': goUnderDay,
+	'a': goWeekDay,
+	'A': goLongWeekDay,
+	'H': goHour,
+	'I': goZeroHour12,
+	'M': goZeroMinute,
+	'S': goZeroSecond,
+	'p': goPM,
+	'Z': goTZ,
+	'z': goNumTZ,
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'R': "15:04",
+	'D': "01/02/06",
+	'x': "01/02/06",
+	'X': "15:04:05",
+	'c': "Mon Jan _2 15:04:05 2006",
+}
+
+var strftimeLiteralTokens = map[byte]string{
+	'%': "%",
+	'n': "\n",
+	't': "\t",
+}
+
+func LayoutFromStrftime(format string) (string, error) {
+	b := layoutBuilder{source: format}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.literal(format[i : i+1])
+			continue
+		}
+
+		if i+1 >= len(format) {
+			b.fail("trailing %")
+			break
+		}
+		i++
+
+		c := format[i]
+		switch {
+		case c == 'h':
+			b.chunk(goMonth)
+		case c == 'f':
+			b.fraction(6)
+		case c == 'L':
+			b.fraction(3)
+		case c == 'N':
+			b.fraction(9)
+		default:
+			if chunk, ok := strftimeLayoutTokens[c]; ok {
+				b.chunk(chunk)
+			} else if literal, ok := strftimeLiteralTokens[c]; ok {
+				b.literal(literal)
+			} else {
+				b.fail(fmt.Sprintf("token %%%c", c))
+			}
+		}
+	}
+	return b.result()
+}
+
+func LayoutToStrftime(layout string) (string, error) {
+	chunks := map[string]string{}
+	for token, chunk := range strftimeLayoutTokens {
+		if isGoLayoutChunk(chunk) {
+			chunks[chunk] = "%" + string(token)
+		}
+	}
+
+	return translateFromGo(layout, "strftime", chunks, func(n int) string {
+		switch n {
+		case 3:
+			return "%L"
+		case 6:
+			return "%f"
+		case 9:
+			return "%N"
+		}
+		return ""
+	}, func(literal string) string {
+		return strings.Replace(literal, "%", "%%", -1)
+	})
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var javaLayoutTokens = map[string]string{
+	"yyyy":  goLongYear,
+	"yy":    goYear,
+	"y":     goLongYear,
+	"uuuu":  goLongYear,
+	"uu":    goYear,
+	"u":     goLongYear,
+	"MMMM":  goLongMonth,
+	"MMM":   goMonth,
+	"MM":    goZeroMonth,
+	"M":     goNumMonth,
+	"LLLL":  goLongMonth,
+	"LLL":   goMonth,
+	"LL":    goZeroMonth,
+	"L":     goNumMonth,
+	"dd":    goZeroDay,
+	"d":     goDay,
+	"EEEE":  goLongWeekDay,
+	"EEE":   goWeekDay,
+	"EE":    goWeekDay,
+	"E":     goWeekDay,
+	"a":     goPM,
+	"HH":    goHour,
+	"hh":    goZeroHour12,
+	"h":     goHour12,
+	"mm":    goZeroMinute,
+	"m":     goMinute,
+	"ss":    goZeroSecond,
+	"s":     goSecond,
+	"XXX":   goISO8601ColonTZ,
+	"XX":    goISO8601TZ,
+	"X":     goISO8601ShortTZ,
+	"xxx":   goNumColonTZ,
+	"xx":    goNumTZ,
+	"x":     goNumShortTZ,
+	"ZZZZZ": goISO8601ColonTZ,
+	"ZZZ":   goNumTZ,
+	"ZZ":    goNumTZ,
+	"Z":     goNumTZ,
+	"zzz":   goTZ,
+	"zz":    goTZ,
+	"z":     goTZ,
+}
+
+func LayoutFromJava(format string) (string, error) {
+	b := layoutBuilder{source: format}
+	for i := 0; i < len(format); {
+		c := format[i]
+
+		if c == '\'' {
+			if i+1 < len(format) && format[i+1] == '\'' {
+				b.literal("'")
+				i += 2
+				continue
+			}
+
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				b.fail("unterminated quote")
+				break
+			}
+			b.literal(strings.Replace(format[i+1:i+1+end], "''", "'", -1))
+			i += end + 2
+			continue
+		}
+
+		if !isLayoutLetter(c) {
+			b.literal(format[i : i+1])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(format) && format[j] == c {
+			j++
+		}
+		token := format[i:j]
+		i = j
+
+		if c == 'S' {
+			b.fraction(len(token))
+		} else if chunk, ok := javaLayoutTokens[token]; ok {
+			b.chunk(chunk)
+		} else {
+			b.fail(fmt.Sprintf("pattern %q", token))
+		}
+	}
+	return b.result()
+}
+
+var javaLayoutChunks = map[string]string{
+	goLongYear:       "yyyy",
+	goYear:           "yy",
+	goLongMonth:      "MMMM",
+	goMonth:          "MMM",
+	goZeroMonth:      "MM",
+	goNumMonth:       "M",
+	goZeroDay:        "dd",
+	goDay:            "d",
+	goLongWeekDay:    "EEEE",
+	goWeekDay:        "EEE",
+	goPM:             "a",
+	goHour:           "HH",
+	goZeroHour12:     "hh",
+	goHour12:         "h",
+	goZeroMinute:     "mm",
+	goMinute:         "m",
+	goZeroSecond:     "ss",
+	goSecond:         "s",
+	goISO8601ColonTZ: "XXX",
+	goISO8601TZ:      "XX",
+	goISO8601ShortTZ: "X",
+	goNumColonTZ:     "xxx",
+	goNumTZ:          "xx",
+	goNumShortTZ:     "x",
+	goTZ:             "zzz",
+}
+
+func LayoutToJava(layout string) (string, error) {
+	chunks := javaLayoutChunks
+	return translateFromGo(layout, "Java", chunks, func(n int) string {
+		return strings.Repeat("S", n)
+	}, func(literal string) string {
+		var builder strings.Builder
+		quoted := false
+		for i := 0; i < len(literal); i++ {
+			c := literal[i]
+			if c == '\'' {
+				builder.WriteString("''")
+				continue
+			}
+			if isLayoutLetter(c) != quoted {
+				builder.WriteByte('\'')
+				quoted = !quoted
+			}
+			builder.WriteByte(c)
+		}
+		if quoted {
+			builder.WriteByte('\'')
+		}
+		return builder.String()
+	})
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var momentLayoutTokens = []struct {
+	token string
+	chunk string
+}{
+	{"YYYY", goLongYear},
+	{"YY", goYear},
+	{"MMMM", goLongMonth},
+	{"MMM", goMonth},
+	{"MM", goZeroMonth},
+	{"M", goNumMonth},
+	{"DD", goZeroDay},
+	{"D", goDay},
+	{"dddd", goLongWeekDay},
+	{"ddd", goWeekDay},
+	{"HH", goHour},
+	{"hh", goZeroHour12},
+	{"h", goHour12},
+	{"mm", goZeroMinute},
+	{"m", goMinute},
+	{"ss", goZeroSecond},
+	{"s", goSecond},
+	{"A", goPM},
+	{"a", gopm},
+	{"ZZ", goNumTZ},
+	{"Z", goNumColonTZ},
+}
+
+// momentUntranslatableTokens include the ordinals such as "Mo", the locale formats such as "LT" and the free-width year "Y".
+var momentUntranslatableTokens = []string{
+	"Mo", "Do", "DDDo", "do", "Qo", "Wo", "wo",
+	"LTS", "LT", "LLLL", "LLL", "LL", "L", "llll", "lll", "ll", "l",
+	"DDDD", "DDD", "dd", "d", "E", "e", "H", "k", "Q", "W", "w", "G", "g", "X", "x", "N", "z", "Y",
+}
+
+func LayoutFromMoment(format string) (string, error) {
+	b := layoutBuilder{source: format}
+	for i := 0; i < len(format); {
+		rest := format[i:]
+
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				b.fail("unterminated bracket")
+				break
+			}
+			b.literal(rest[1:end])
+			i += end + 1
+			continue
+		}
+
+		if rest[0] == 'S' {
+			j := 0
+			for j < len(rest) && rest[j] == 'S' {
+				j++
+			}
+			b.fraction(j)
+			i += j
+			continue
+		}
+
+		token, chunk := "", ""
+		for _, candidate := range momentLayoutTokens {
+			if strings.HasPrefix(rest, candidate.token) && len(candidate.token) > len(token) {
+				token, chunk = candidate.token, candidate.chunk
+			}
+		}
+		for _, candidate := range momentUntranslatableTokens {
+			if strings.HasPrefix(rest, candidate) && len(candidate) > len(token) {
+				token, chunk = candidate, ""
+			}
+		}
+
+		if 0 < len(token) {
+			if 0 < len(chunk) {
+				b.chunk(chunk)
+			} else {
+				b.fail(fmt.Sprintf("token %q", token))
+			}
+			i += len(token)
+			continue
+		}
+
+		b.literal(rest[:1])
+		i++
+	}
+	return b.result()
+}
+
+func LayoutToMoment(layout string) (string, error) {
+	chunks := map[string]string{}
+	for _, token := range momentLayoutTokens {
+		chunks[token.chunk] = token.token
+	}
+
+	return translateFromGo(layout, "moment.js", chunks, func(n int) string {
+		return strings.Repeat("S", n)
+	}, func(literal string) string {
+		for i := 0; i < len(literal); i++ {
+			if isLayoutLetter(literal[i]) {
+				return "[" + literal + "]"
+			}
+		}
+		return literal
+	})
+}
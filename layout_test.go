@@ -0,0 +1,133 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_layout_FromForeign(t *testing.T) {
+	a := assert.New(t)
+
+	cases := []struct {
+		convert  func(string) (string, error)
+		format   string
+		expected string
+	}{
+		{LayoutFromPHP, "Y-m-d H:i:s", DefaultDateLayout},
+		{LayoutFromPHP, "D, d M Y H:i:s.u P", "Mon, 02 Jan 2006 15:04:05.000000 -07:00"},
+		{LayoutFromPHP, "\\T\\o\\d\\a\\y: Y/n/j", "Today: 2006/1/2"},
+		{LayoutFromStrftime, "%Y-%m-%d %H:%M:%S", DefaultDateLayout},
+		{LayoutFromStrftime, "%F %T.%f %z", "2006-01-02 15:04:05.000000 -0700"},
+		{LayoutFromStrftime, "%b %e, %Y (%%)", "Jan _2, 2006 (%)"},
+		{LayoutFromJava, "yyyy-MM-dd HH:mm:ss", DefaultDateLayout},
+		{LayoutFromJava, "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "2006-01-02T15:04:05.000Z07:00"},
+		{LayoutFromJava, "EEEE, MMMM d, ''yy", "Monday, January 2, '06"},
+		{LayoutFromMoment, "YYYY-MM-DD HH:mm:ss", DefaultDateLayout},
+		{LayoutFromMoment, "YYYY-MM-DDTHH:mm:ss.SSSZ", "2006-01-02T15:04:05.000-07:00"},
+		{LayoutFromMoment, "[Today is] dddd h:mm a", "Today is Monday 3:04 pm"},
+	}
+
+	for _, c := range cases {
+		layout, err := c.convert(c.format)
+		a.Nil(err, c.format)
+		a.Equal(c.expected, layout, c.format)
+	}
+
+	failures := []struct {
+		convert func(string) (string, error)
+		format  string
+	}{
+		{LayoutFromPHP, "jS F Y"},
+		{LayoutFromPHP, "Y-m-d 1"},
+		{LayoutFromPHP, "His u"},
+		{LayoutFromStrftime, "%j"},
+		{LayoutFromStrftime, "%Y %"},
+		{LayoutFromJava, "yyyy-MM-dd H:mm"},
+		{LayoutFromJava, "hh:mm 'PM'"},
+		{LayoutFromMoment, "Do MMMM"},
+		// Literal text that Go would read as an element, also when written a character at a time.
+		{LayoutFromStrftime, "Mon %d"},
+		{LayoutFromStrftime, "%d Jan"},
+		{LayoutFromStrftime, "%H MST"},
+		{LayoutFromPHP, "H \\P\\M"},
+		{LayoutFromPHP, "\\J\\a\\n d"},
+		{LayoutFromJava, "'Mon' d"},
+		{LayoutFromMoment, "[MST] HH"},
+		// Chunks running into a neighbour, the month "1" and second "5" make the hour "15".
+		{LayoutFromJava, "Ms"},
+		{LayoutFromMoment, "Ms"},
+		{LayoutFromPHP, "n5"},
+		// Ordinals, locale formats and the free-width year.
+		{LayoutFromMoment, "Mo"},
+		{LayoutFromMoment, "L"},
+		{LayoutFromMoment, "LT"},
+		{LayoutFromMoment, "Y-MM"},
+	}
+
+	for _, c := range failures {
+		_, err := c.convert(c.format)
+		a.True(errors.Is(err, ErrUntranslatableLayout), c.format)
+	}
+}
+
+func Test_layout_ToForeign(t *testing.T) {
+	a := assert.New(t)
+
+	layout := "2006-01-02T15:04:05.000000-07:00"
+
+	php, err := LayoutToPHP(layout)
+	a.Nil(err)
+	a.Equal("Y-m-d\\TH:i:s.uP", php)
+
+	strftime, err := LayoutToStrftime("2006-01-02T15:04:05.000000-0700")
+	a.Nil(err)
+	a.Equal("%Y-%m-%dT%H:%M:%S.%f%z", strftime)
+
+	_, err = LayoutToStrftime(layout)
+	a.True(errors.Is(err, ErrUntranslatableLayout))
+
+	java, err := LayoutToJava(layout)
+	a.Nil(err)
+	a.Equal("yyyy-MM-dd'T'HH:mm:ss.SSSSSSxxx", java)
+
+	moment, err := LayoutToMoment(layout)
+	a.Nil(err)
+	a.Equal("YYYY-MM-DD[T]HH:mm:ss.SSSSSSZ", moment)
+
+	_, err = LayoutToMoment(time.Kitchen + " MST")
+	a.True(errors.Is(err, ErrUntranslatableLayout))
+
+	for _, layout := range []string{DefaultDateLayout, DefaultFineDateLayout[:19] + ".000", time.RFC1123Z} {
+		java, err := LayoutToJava(layout)
+		a.Nil(err, layout)
+		back, err := LayoutFromJava(java)
+		a.Nil(err, layout)
+		a.Equal(layout, back)
+	}
+}
+
+func Test_layout_ForeignPrefix(t *testing.T) {
+	a := assert.New(t)
+
+	now, err := ParseWithLayout("php:Y-m-d H:i:s", "2021-01-02 03:04:05")
+	a.Nil(err)
+	a.Equal("2021-01-02 03:04:05", Format(now))
+
+	a.Equal("02/01/2021", FormatWithLayout("strftime:%d/%m/%Y", now))
+	a.Equal("strftime:%j", FormatWithLayout("strftime:%j", now))
+	value, err := FormatWithLayoutE("strftime:%j", now)
+	a.True(errors.Is(err, ErrUntranslatableLayout))
+	a.Equal("", value)
+	value, err = FormatWithLayoutE("strftime:%d/%m/%Y", now)
+	a.Nil(err)
+	a.Equal("02/01/2021", value)
+
+	_, err = ParseWithLayout("java:yyyy-DDD", "2021-002")
+	a.True(errors.Is(err, ErrUntranslatableLayout))
+
+	timestamps := Timestamps{}
+	a.Nil(timestamps.SetCreatedAtWithLayout("moment:YYYY-MM-DD", "2021-01-02"))
+	a.Equal("2021-01-02", timestamps.GetCreatedAtWithLayout("java:yyyy-MM-dd"))
+}
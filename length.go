@@ -0,0 +1,467 @@
+package timestamps
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidISO8601Duration = errors.New("timestamps: invalid ISO 8601 duration")
+var ErrNominalISO8601Duration = errors.New("timestamps: ISO 8601 duration with years or months has no fixed length")
+
+const (
+	LengthDay         = 24 * time.Hour
+	LengthHour        = time.Hour
+	LengthMinute      = time.Minute
+	LengthSecond      = time.Second
+	LengthMillisecond = time.Millisecond
+	LengthMicrosecond = time.Microsecond
+	LengthNanosecond  = time.Nanosecond
+)
+
+var lengthUnits = []time.Duration{
+	LengthDay,
+	LengthHour,
+	LengthMinute,
+	LengthSecond,
+	LengthMillisecond,
+	LengthMicrosecond,
+	LengthNanosecond,
+}
+
+type LengthStyle int
+
+const (
+	LengthCompact LengthStyle = iota
+	LengthISO8601
+	LengthVerbose
+)
+
+type LengthRounding int
+
+const (
+	LengthTruncate LengthRounding = iota
+	LengthRound
+	LengthCeil
+)
+
+type LengthLocale interface {
+	Unit(unit time.Duration, n uint64) string
+	Separator() string
+}
+
+type LengthOptions struct {
+	Style LengthStyle
+	// Largest and Smallest are one of the Length* unit constants, zero means LengthDay and LengthSecond.
+	Largest  time.Duration
+	Smallest time.Duration
+	Rounding LengthRounding
+	// Locale is only used by LengthVerbose.
+	Locale LengthLocale
+}
+
+var DefaultLengthOptions = LengthOptions{
+	Style:    LengthCompact,
+	Largest:  LengthDay,
+	Smallest: LengthSecond,
+	Rounding: LengthTruncate,
+	Locale:   EnglishLengthLocale,
+}
+
+func FormatLength(length time.Duration, options LengthOptions) string {
+	if options.Largest <= 0 {
+		options.Largest = LengthDay
+	}
+	if options.Smallest <= 0 {
+		options.Smallest = LengthSecond
+	}
+	if options.Smallest > options.Largest {
+		options.Smallest = options.Largest
+	}
+	if options.Locale == nil {
+		options.Locale = EnglishLengthLocale
+	}
+
+	// The magnitude is kept unsigned, -math.MinInt64 and a rounded up math.MaxInt64 do not fit time.Duration.
+	negative := length < 0
+	magnitude := uint64(length)
+	if negative {
+		magnitude = -magnitude
+	}
+	magnitude = roundLength(magnitude, uint64(options.Smallest), options.Rounding)
+
+	var result string
+	switch options.Style {
+	case LengthISO8601:
+		result = formatISO8601Length(magnitude, options)
+	case LengthVerbose:
+		result = formatVerboseLength(magnitude, options)
+	default:
+		result = formatCompactLength(magnitude, options)
+	}
+
+	if negative && magnitude != 0 {
+		return "-" + result
+	}
+	return result
+}
+
+func roundLength(length uint64, unit uint64, rounding LengthRounding) uint64 {
+	remainder := length % unit
+	if remainder == 0 {
+		return length
+	}
+
+	switch rounding {
+	case LengthRound:
+		if remainder*2 >= unit {
+			return length - remainder + unit
+		}
+	case LengthCeil:
+		return length - remainder + unit
+	}
+	return length - remainder
+}
+
+type lengthPart struct {
+	unit time.Duration
+	n    uint64
+}
+
+func splitLength(length uint64, largest time.Duration, smallest time.Duration) []lengthPart {
+	var parts []lengthPart
+	for _, unit := range lengthUnits {
+		if unit > largest || unit < smallest {
+			continue
+		}
+
+		n := length / uint64(unit)
+		length -= n * uint64(unit)
+		if n > 0 {
+			parts = append(parts, lengthPart{unit: unit, n: n})
+		}
+	}
+	return parts
+}
+
+func formatCompactLength(length uint64, options LengthOptions) string {
+	parts := splitLength(length, options.Largest, options.Smallest)
+	if 0 >= len(parts) {
+		return "0" + compactLengthUnit(options.Smallest)
+	}
+
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteString(strconv.FormatUint(part.n, 10))
+		builder.WriteString(compactLengthUnit(part.unit))
+	}
+	return builder.String()
+}
+
+func compactLengthUnit(unit time.Duration) string {
+	switch unit {
+	case LengthDay:
+		return "d"
+	case LengthHour:
+		return "h"
+	case LengthMinute:
+		return "m"
+	case LengthSecond:
+		return "s"
+	case LengthMillisecond:
+		return "ms"
+	case LengthMicrosecond:
+		return "µs"
+	}
+	return "ns"
+}
+
+func formatVerboseLength(length uint64, options LengthOptions) string {
+	parts := splitLength(length, options.Largest, options.Smallest)
+	if 0 >= len(parts) {
+		return options.Locale.Unit(options.Smallest, 0)
+	}
+
+	words := make([]string, 0, len(parts))
+	for _, part := range parts {
+		words = append(words, options.Locale.Unit(part.unit, part.n))
+	}
+	return strings.Join(words, options.Locale.Separator())
+}
+
+func formatISO8601Length(length uint64, options LengthOptions) string {
+	largest := options.Largest
+	if largest < LengthSecond {
+		largest = LengthSecond
+	}
+
+	var days, hours, minutes uint64
+	if largest >= LengthDay {
+		days = length / uint64(LengthDay)
+		length -= days * uint64(LengthDay)
+	}
+	if largest >= LengthHour {
+		hours = length / uint64(LengthHour)
+		length -= hours * uint64(LengthHour)
+	}
+	if largest >= LengthMinute {
+		minutes = length / uint64(LengthMinute)
+		length -= minutes * uint64(LengthMinute)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("P")
+	if days > 0 {
+		builder.WriteString(strconv.FormatUint(days, 10) + "D")
+	}
+	if hours > 0 || minutes > 0 || length > 0 || days == 0 {
+		builder.WriteString("T")
+	}
+	if hours > 0 {
+		builder.WriteString(strconv.FormatUint(hours, 10) + "H")
+	}
+	if minutes > 0 {
+		builder.WriteString(strconv.FormatUint(minutes, 10) + "M")
+	}
+	if length > 0 || (days == 0 && hours == 0 && minutes == 0) {
+		builder.WriteString(formatISO8601Seconds(length) + "S")
+	}
+	return builder.String()
+}
+
+func formatISO8601Seconds(length uint64) string {
+	seconds := strconv.FormatUint(length/uint64(time.Second), 10)
+	if fraction := length % uint64(time.Second); fraction > 0 {
+		return seconds + "." + strings.TrimRight(fmt.Sprintf("%09d", fraction), "0")
+	}
+	return seconds
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+type isoPeriod struct {
+	negative bool
+	years    int
+	months   int
+	days     int
+	clock    time.Duration
+}
+
+func (p isoPeriod) isNominal() bool {
+	return p.years != 0 || p.months != 0
+}
+
+func (p isoPeriod) addTo(now time.Time) time.Time {
+	if p.negative {
+		return now.AddDate(-p.years, -p.months, -p.days).Add(-p.clock)
+	}
+	return now.AddDate(p.years, p.months, p.days).Add(p.clock)
+}
+
+func (p isoPeriod) subFrom(now time.Time) time.Time {
+	p.negative = !p.negative
+	return p.addTo(now)
+}
+
+func parseISOPeriod(value string) (isoPeriod, error) {
+	return parseISOPeriodFields(value, false)
+}
+
+// parseISOPeriodFields reads a duration, signedFields allows a sign on every number as in the PostgreSQL iso_8601
+// interval style "P-1DT-2H-3M-4.5S".
+func parseISOPeriodFields(value string, signedFields bool) (isoPeriod, error) {
+	period := isoPeriod{}
+	s := value
+
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		period.negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") || len(s) < 2 {
+		return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+	}
+	s = s[1:]
+
+	inTime := false
+	components := 0
+	for 0 < len(s) {
+		if s[0] == 'T' {
+			if inTime || len(s) < 2 {
+				return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := 0
+		if signedFields && (s[0] == '-' || s[0] == '+') {
+			i++
+		}
+		digits := i
+		for i < len(s) && (('0' <= s[i] && s[i] <= '9') || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == digits || i == len(s) {
+			return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+		}
+
+		number, err := strconv.ParseFloat(strings.Replace(s[:i], ",", ".", 1), 64)
+		if err != nil {
+			return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+		}
+		whole, fraction := math.Modf(number)
+
+		designator := s[i]
+		s = s[i+1:]
+		components++
+
+		// Years, months, weeks and days are counted in int, larger numbers cannot be meant.
+		if !inTime && (whole >= math.MaxInt32 || whole <= math.MinInt32) {
+			return isoPeriod{}, fmt.Errorf("%w: %q: number too large", ErrInvalidISO8601Duration, value)
+		}
+
+		var clock time.Duration
+		switch {
+		case !inTime && designator == 'Y' && fraction == 0:
+			period.years += int(whole)
+		case !inTime && designator == 'M' && fraction == 0:
+			period.months += int(whole)
+		case !inTime && designator == 'W':
+			period.days += int(whole) * 7
+			clock, err = isoLength(fraction, 7*LengthDay)
+		case !inTime && designator == 'D':
+			period.days += int(whole)
+			clock, err = isoLength(fraction, LengthDay)
+		case inTime && designator == 'H':
+			clock, err = isoLength(number, time.Hour)
+		case inTime && designator == 'M':
+			clock, err = isoLength(number, time.Minute)
+		case inTime && designator == 'S':
+			clock, err = isoLength(number, time.Second)
+		default:
+			return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+		}
+		if err == nil {
+			period.clock, err = addLength(period.clock, clock)
+		}
+		if err != nil {
+			return isoPeriod{}, fmt.Errorf("%w: %q: %v", ErrInvalidISO8601Duration, value, err)
+		}
+	}
+
+	if components == 0 {
+		return isoPeriod{}, fmt.Errorf("%w: %q", ErrInvalidISO8601Duration, value)
+	}
+	return period, nil
+}
+
+func ParseISO8601Duration(value string) (time.Duration, error) {
+	period, err := parseISOPeriod(value)
+	if err != nil {
+		return 0, err
+	}
+	return isoPeriodLength(value, period)
+}
+
+// isoPeriodLength returns the length of a period parsed from value, which only names it in errors.
+func isoPeriodLength(value string, period isoPeriod) (time.Duration, error) {
+	if period.isNominal() {
+		return 0, fmt.Errorf("%w: %q", ErrNominalISO8601Duration, value)
+	}
+
+	length, err := isoLength(float64(period.days), LengthDay)
+	if err == nil {
+		length, err = addLength(length, period.clock)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %v", ErrInvalidISO8601Duration, value, err)
+	}
+
+	if period.negative {
+		return -length, nil
+	}
+	return length, nil
+}
+
+// isoLength is number of unit as a time.Duration, rounded to the nanosecond.
+func isoLength(number float64, unit time.Duration) (time.Duration, error) {
+	length := math.Round(number * float64(unit))
+	if length >= -math.MinInt64 || length < math.MinInt64 {
+		return 0, errors.New("length overflows time.Duration")
+	}
+	return time.Duration(length), nil
+}
+
+func addLength(length time.Duration, part time.Duration) (time.Duration, error) {
+	if (part > 0 && length > math.MaxInt64-part) || (part < 0 && length < math.MinInt64-part) {
+		return 0, errors.New("length overflows time.Duration")
+	}
+	return length + part, nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var EnglishLengthLocale LengthLocale = englishLengthLocale{}
+
+type englishLengthLocale struct{}
+
+func (englishLengthLocale) Unit(unit time.Duration, n uint64) string {
+	name := "nanosecond"
+	switch unit {
+	case LengthDay:
+		name = "day"
+	case LengthHour:
+		name = "hour"
+	case LengthMinute:
+		name = "minute"
+	case LengthSecond:
+		name = "second"
+	case LengthMillisecond:
+		name = "millisecond"
+	case LengthMicrosecond:
+		name = "microsecond"
+	}
+
+	if n != 1 {
+		name += "s"
+	}
+	return fmt.Sprintf("%d %s", n, name)
+}
+
+func (englishLengthLocale) Separator() string {
+	return " "
+}
+
+var ChineseLengthLocale LengthLocale = chineseLengthLocale{}
+
+type chineseLengthLocale struct{}
+
+func (chineseLengthLocale) Unit(unit time.Duration, n uint64) string {
+	name := "纳秒"
+	switch unit {
+	case LengthDay:
+		name = "天"
+	case LengthHour:
+		name = "小时"
+	case LengthMinute:
+		name = "分钟"
+	case LengthSecond:
+		name = "秒"
+	case LengthMillisecond:
+		name = "毫秒"
+	case LengthMicrosecond:
+		name = "微秒"
+	}
+	return fmt.Sprintf("%d%s", n, name)
+}
+
+func (chineseLengthLocale) Separator() string {
+	return ""
+}
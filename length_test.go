@@ -0,0 +1,66 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_length_FormatLength(t *testing.T) {
+	a := assert.New(t)
+
+	length := 26*time.Hour + 30*time.Minute + 1500*time.Millisecond
+
+	a.Equal("1d2h30m1s", FormatLength(length, DefaultLengthOptions))
+	a.Equal("P1DT2H30M1S", FormatLength(length, LengthOptions{Style: LengthISO8601}))
+	a.Equal("P1DT2H30M1.5S", FormatLength(length, LengthOptions{Style: LengthISO8601, Smallest: LengthMillisecond}))
+	a.Equal("PT26H31M", FormatLength(length, LengthOptions{Style: LengthISO8601, Largest: LengthHour, Smallest: LengthMinute, Rounding: LengthCeil}))
+	a.Equal("PT0S", FormatLength(0, LengthOptions{Style: LengthISO8601}))
+	a.Equal("1 day 2 hours 30 minutes 1 second", FormatLength(length, LengthOptions{Style: LengthVerbose}))
+	a.Equal("26小时30分钟", FormatLength(length, LengthOptions{Style: LengthVerbose, Largest: LengthHour, Smallest: LengthMinute, Locale: ChineseLengthLocale}))
+	a.Equal("-1h30m", FormatLength(-90*time.Minute, DefaultLengthOptions))
+
+	duration := Duration{}
+	duration.SetStartedAt(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	duration.SetEndedAt(time.Date(2021, 1, 1, 1, 30, 0, 0, time.UTC))
+	a.Equal("PT1H30M", duration.FormatLength(LengthOptions{Style: LengthISO8601}))
+
+	// The extremes of time.Duration neither wrap when negated nor when rounded up.
+	a.Equal("-2562047h47m16s854ms775µs808ns", FormatLength(math.MinInt64, LengthOptions{Largest: LengthHour, Smallest: LengthNanosecond}))
+	a.Equal("PT2562047H47M17S", FormatLength(math.MaxInt64, LengthOptions{Style: LengthISO8601, Largest: LengthHour, Rounding: LengthCeil}))
+	a.Equal("-9223372036854775808 nanoseconds", FormatLength(math.MinInt64, LengthOptions{Style: LengthVerbose, Largest: LengthNanosecond, Smallest: LengthNanosecond}))
+}
+
+func Test_length_ParseISO8601Duration(t *testing.T) {
+	a := assert.New(t)
+
+	cases := map[string]time.Duration{
+		"PT1H30M":     90 * time.Minute,
+		"P1DT2H":      26 * time.Hour,
+		"P1W":         7 * 24 * time.Hour,
+		"PT1.5S":      1500 * time.Millisecond,
+		"PT0,25H":     15 * time.Minute,
+		"-PT15M":      -15 * time.Minute,
+		"PT0S":        0,
+		"P0DT0H0M10S": 10 * time.Second,
+	}
+	for value, expected := range cases {
+		length, err := ParseISO8601Duration(value)
+		a.Nil(err, value)
+		a.Equal(expected, length, value)
+	}
+
+	length, err := ParseISO8601Duration("PT2562047H47M16.854775807S")
+	a.Nil(err)
+	a.Equal(time.Duration(math.MaxInt64), length)
+
+	for _, value := range []string{"", "P", "PT", "1H", "P1H", "PT1D", "P1.5Y", "PTH", "PT9999999999999H", "P106752D", "PT2562047H47M17S", "P99999999999D", "PT-2H"} {
+		_, err := ParseISO8601Duration(value)
+		a.True(errors.Is(err, ErrInvalidISO8601Duration), value)
+	}
+
+	_, err = ParseISO8601Duration("P1M")
+	a.True(errors.Is(err, ErrNominalISO8601Duration))
+}
@@ -0,0 +1,434 @@
+package timestamps
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Times are written as RFC 3339 with nanoseconds, an invalid time is null in JSON and YAML,
+// left out of TOML and XML, and the empty string as text.
+//
+// Timestamps and Duration have no encoding methods of their own, those would be promoted into every model embedding
+// them and hide its other fields. Their YAML, TOML and XML encodings live on the TimestampsDocument and
+// DurationDocument wrappers, to be used as named fields or around the value being encoded.
+
+// XMLNaming decides the element or attribute names of TimestampsDocument and DurationDocument in XML,
+// empty names fall back to the YAML keys such as created_at.
+type XMLNaming struct {
+	CreatedAt string
+	UpdatedAt string
+	DeletedAt string
+	StartedAt string
+	EndedAt   string
+	// Attributes writes the times as attributes of the enclosing element instead of child elements.
+	Attributes bool
+}
+
+// timeKeys are the keys of the YAML and TOML encodings.
+var timeKeys = XMLNaming{
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	DeletedAt: "deleted_at",
+	StartedAt: "started_at",
+	EndedAt:   "ended_at",
+}
+
+func (n XMLNaming) orKeys() XMLNaming {
+	for _, name := range []struct {
+		value *string
+		key   string
+	}{
+		{&n.CreatedAt, timeKeys.CreatedAt},
+		{&n.UpdatedAt, timeKeys.UpdatedAt},
+		{&n.DeletedAt, timeKeys.DeletedAt},
+		{&n.StartedAt, timeKeys.StartedAt},
+		{&n.EndedAt, timeKeys.EndedAt},
+	} {
+		if 0 >= len(*name.value) {
+			*name.value = name.key
+		}
+	}
+	return n
+}
+
+func marshalTimeText(t sql.NullTime) string {
+	return FormatWithLayout(DefaultRFC3339NanoDateLayout, t)
+}
+
+func unmarshalTimeText(value string) (sql.NullTime, error) {
+	if 0 >= len(value) {
+		return NilTime(), nil
+	}
+
+	if now, err := time.Parse(DefaultRFC3339NanoDateLayout, value); err != nil {
+		return NilTime(), err
+	} else {
+		return validTime(now), nil
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(marshalTimeText(t.NullTime))
+}
+
+func (t *NullTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = NullTime{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(value))
+}
+
+func (t NullTime) MarshalText() ([]byte, error) {
+	return []byte(marshalTimeText(t.NullTime)), nil
+}
+
+func (t *NullTime) UnmarshalText(data []byte) error {
+	if now, err := unmarshalTimeText(string(data)); err != nil {
+		return err
+	} else {
+		*t = NewNullTime(now)
+		return nil
+	}
+}
+
+func (t NullTime) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return marshalTimeText(t.NullTime), nil
+}
+
+// UnmarshalYAML also accepts the YAML timestamp forms such as "2001-12-14 21:59:43.10 -5" and "2002-12-14".
+// An empty string makes t invalid, a null is not passed in by yaml.v3 and leaves t unchanged.
+// It takes the unmarshal callback that yaml.v3 still supports, so this package does not depend on a YAML library.
+func (t *NullTime) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		if 0 >= len(value) {
+			*t = NullTime{}
+			return nil
+		}
+		if now, err := unmarshalTimeText(value); err == nil {
+			*t = NewNullTime(now)
+			return nil
+		}
+	}
+
+	var now time.Time
+	if err := unmarshal(&now); err != nil {
+		return err
+	}
+	*t = NewNullTime(validTime(now))
+	return nil
+}
+
+// MarshalXML leaves an invalid time out.
+func (t NullTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !t.Valid {
+		return nil
+	}
+	return e.EncodeElement(marshalTimeText(t.NullTime), start)
+}
+
+func (t *NullTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	return t.UnmarshalText(bytes.TrimSpace([]byte(value)))
+}
+
+func (t NullTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !t.Valid {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: marshalTimeText(t.NullTime)}, nil
+}
+
+func (t *NullTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.UnmarshalText([]byte(attr.Value))
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+type timestampsDocument struct {
+	CreatedAt NullTime `yaml:"created_at"`
+	UpdatedAt NullTime `yaml:"updated_at"`
+	DeletedAt NullTime `yaml:"deleted_at"`
+}
+
+func (t *Timestamps) document() timestampsDocument {
+	return timestampsDocument{
+		CreatedAt: NewNullTime(t.CreatedAt),
+		UpdatedAt: NewNullTime(t.UpdatedAt),
+		DeletedAt: NewNullTime(t.DeletedAt),
+	}
+}
+
+func (t *Timestamps) setDocument(document timestampsDocument) {
+	t.CreatedAt = document.CreatedAt.NullTime
+	t.UpdatedAt = document.UpdatedAt.NullTime
+	t.DeletedAt = document.DeletedAt.NullTime
+}
+
+type durationDocument struct {
+	StartedAt NullTime `yaml:"started_at"`
+	EndedAt   NullTime `yaml:"ended_at"`
+}
+
+func (t *Duration) document() durationDocument {
+	return durationDocument{
+		StartedAt: NewNullTime(t.StartedAt),
+		EndedAt:   NewNullTime(t.EndedAt),
+	}
+}
+
+func (t *Duration) setDocument(document durationDocument) {
+	t.StartedAt = document.StartedAt.NullTime
+	t.EndedAt = document.EndedAt.NullTime
+}
+
+// namedTime pairs a field with its key or XML name.
+type namedTime struct {
+	name  string
+	value *sql.NullTime
+}
+
+func (t *Timestamps) namedTimes(naming XMLNaming) []namedTime {
+	return []namedTime{
+		{name: naming.CreatedAt, value: &t.CreatedAt},
+		{name: naming.UpdatedAt, value: &t.UpdatedAt},
+		{name: naming.DeletedAt, value: &t.DeletedAt},
+	}
+}
+
+func (t *Duration) namedTimes(naming XMLNaming) []namedTime {
+	return []namedTime{
+		{name: naming.StartedAt, value: &t.StartedAt},
+		{name: naming.EndedAt, value: &t.EndedAt},
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// TimestampsDocument encodes Timestamps in YAML, TOML and XML, e.g. as a named field of a record:
+//
+//	type Record struct {
+//		Name       string                        `yaml:"name"`
+//		Timestamps timestamps.TimestampsDocument `yaml:"timestamps"`
+//	}
+type TimestampsDocument struct {
+	Timestamps
+	// XMLNaming names the XML elements or attributes of this value, set it before decoding too.
+	XMLNaming XMLNaming
+}
+
+// DurationDocument encodes Duration in YAML, TOML and XML, see TimestampsDocument.
+type DurationDocument struct {
+	Duration
+	XMLNaming XMLNaming
+}
+
+func (t TimestampsDocument) MarshalYAML() (interface{}, error) {
+	return t.Timestamps.document(), nil
+}
+
+func (t *TimestampsDocument) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	document := timestampsDocument{}
+	if err := unmarshal(&document); err != nil {
+		return err
+	}
+	t.Timestamps.setDocument(document)
+	return nil
+}
+
+func (t DurationDocument) MarshalYAML() (interface{}, error) {
+	return t.Duration.document(), nil
+}
+
+func (t *DurationDocument) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	document := durationDocument{}
+	if err := unmarshal(&document); err != nil {
+		return err
+	}
+	t.Duration.setDocument(document)
+	return nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// MarshalTOML writes an inline table of TOML datetimes, keyed like the YAML encoding.
+func (t TimestampsDocument) MarshalTOML() ([]byte, error) {
+	return marshalTOMLTable(t.Timestamps.namedTimes(timeKeys)), nil
+}
+
+func (t *TimestampsDocument) UnmarshalTOML(data interface{}) error {
+	return unmarshalTOMLTable(data, t.Timestamps.namedTimes(timeKeys))
+}
+
+func (t DurationDocument) MarshalTOML() ([]byte, error) {
+	return marshalTOMLTable(t.Duration.namedTimes(timeKeys)), nil
+}
+
+func (t *DurationDocument) UnmarshalTOML(data interface{}) error {
+	return unmarshalTOMLTable(data, t.Duration.namedTimes(timeKeys))
+}
+
+func marshalTOMLTable(fields []namedTime) []byte {
+	b := &bytes.Buffer{}
+	b.WriteString("{")
+	for _, field := range fields {
+		if !field.value.Valid {
+			continue
+		}
+		if 1 < b.Len() {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(b, " %s = %s", field.name, marshalTimeText(*field.value))
+	}
+	b.WriteString(" }")
+	return b.Bytes()
+}
+
+// unmarshalTOMLTable accepts datetimes and RFC 3339 strings, missing keys leave the field invalid.
+func unmarshalTOMLTable(data interface{}, fields []namedTime) error {
+	table, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("timestamps: cannot unmarshal TOML %T into a table of times", data)
+	}
+
+	for _, field := range fields {
+		switch value := table[field.name].(type) {
+		case nil:
+			*field.value = NilTime()
+		case time.Time:
+			*field.value = validTime(value)
+		case string:
+			if now, err := unmarshalTimeText(value); err != nil {
+				return err
+			} else {
+				*field.value = now
+			}
+		default:
+			return fmt.Errorf("timestamps: cannot unmarshal TOML %T into %s", value, field.name)
+		}
+	}
+	return nil
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// MarshalXML writes the times as child elements or attributes named by XMLNaming.
+func (t TimestampsDocument) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	naming := t.XMLNaming.orKeys()
+	return marshalXMLTimes(e, start, t.Timestamps.namedTimes(naming), naming.Attributes)
+}
+
+func (t *TimestampsDocument) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLTimes(d, start, t.Timestamps.namedTimes(t.XMLNaming.orKeys()))
+}
+
+func (t DurationDocument) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	naming := t.XMLNaming.orKeys()
+	return marshalXMLTimes(e, start, t.Duration.namedTimes(naming), naming.Attributes)
+}
+
+func (t *DurationDocument) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLTimes(d, start, t.Duration.namedTimes(t.XMLNaming.orKeys()))
+}
+
+func marshalXMLTimes(e *xml.Encoder, start xml.StartElement, fields []namedTime, attributes bool) error {
+	if attributes {
+		for _, field := range fields {
+			if field.value.Valid {
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: field.name}, Value: marshalTimeText(*field.value)})
+			}
+		}
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := e.EncodeElement(NewNullTime(*field.value), xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// unmarshalXMLTimes reads both attributes and child elements, unknown ones are skipped.
+func unmarshalXMLTimes(d *xml.Decoder, start xml.StartElement, fields []namedTime) error {
+	for _, field := range fields {
+		*field.value = NilTime()
+	}
+
+	find := func(name string) *sql.NullTime {
+		for _, field := range fields {
+			if field.name == name {
+				return field.value
+			}
+		}
+		return nil
+	}
+
+	for _, attr := range start.Attr {
+		if value := find(attr.Name.Local); value != nil {
+			if now, err := unmarshalTimeText(attr.Value); err != nil {
+				return err
+			} else {
+				*value = now
+			}
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			value := find(token.Name.Local)
+			if value == nil {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			now := NullTime{}
+			if err := d.DecodeElement(&now, &token); err != nil {
+				return err
+			}
+			*value = now.NullTime
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
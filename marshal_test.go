@@ -0,0 +1,183 @@
+package timestamps
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+	"time"
+)
+
+type marshalRecord struct {
+	XMLName    xml.Name           `yaml:"-" xml:"record" toml:"-"`
+	Name       string             `yaml:"name" xml:"name" toml:"name"`
+	Timestamps TimestampsDocument `yaml:"timestamps" xml:"timestamps" toml:"timestamps"`
+	Duration   DurationDocument   `yaml:"duration" xml:"duration" toml:"duration"`
+	CheckedAt  NullTime           `yaml:"checked_at" xml:"checked_at,attr" toml:"checked_at"`
+}
+
+func marshalFixture() marshalRecord {
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123000000, time.UTC)
+	return marshalRecord{
+		Name:       "nightly",
+		Timestamps: TimestampsDocument{Timestamps: Timestamps{CreatedAt: Time(now), UpdatedAt: Time(now.Add(time.Hour))}},
+		Duration:   DurationDocument{Duration: Duration{StartedAt: Time(now)}},
+		CheckedAt:  NewNullTime(Time(now)),
+	}
+}
+
+func assertMarshalRecord(a *assert.Assertions, expected marshalRecord, actual marshalRecord) {
+	a.Equal(expected.Name, actual.Name)
+	for _, pair := range [][2]NullTime{
+		{NewNullTime(expected.Timestamps.CreatedAt), NewNullTime(actual.Timestamps.CreatedAt)},
+		{NewNullTime(expected.Timestamps.UpdatedAt), NewNullTime(actual.Timestamps.UpdatedAt)},
+		{NewNullTime(expected.Timestamps.DeletedAt), NewNullTime(actual.Timestamps.DeletedAt)},
+		{NewNullTime(expected.Duration.StartedAt), NewNullTime(actual.Duration.StartedAt)},
+		{NewNullTime(expected.Duration.EndedAt), NewNullTime(actual.Duration.EndedAt)},
+		{expected.CheckedAt, actual.CheckedAt},
+	} {
+		a.Equal(pair[0].Valid, pair[1].Valid)
+		a.True(pair[0].Time.Equal(pair[1].Time))
+	}
+}
+
+func Test_marshal_NullTime(t *testing.T) {
+	a := assert.New(t)
+
+	now := NewNullTime(Time(time.Date(2021, 7, 1, 12, 30, 0, 5, time.FixedZone("CST", 8*3600))))
+
+	data, err := json.Marshal([]NullTime{now, {}})
+	a.Nil(err)
+	a.Equal(`["2021-07-01T12:30:00.000000005+08:00",null]`, string(data))
+
+	var times []NullTime
+	a.Nil(json.Unmarshal(data, &times))
+	a.True(times[0].Time.Equal(now.Time))
+	a.False(times[1].Valid)
+	a.NotNil(json.Unmarshal([]byte(`["yesterday"]`), &times))
+
+	text, err := now.MarshalText()
+	a.Nil(err)
+	a.Equal("2021-07-01T12:30:00.000000005+08:00", string(text))
+
+	parsed := NullTime{}
+	a.Nil(parsed.UnmarshalText([]byte("")))
+	a.False(parsed.Valid)
+
+	// YAML timestamps other than RFC 3339 are accepted as well.
+	a.Nil(yaml.Unmarshal([]byte("2002-12-14"), &parsed))
+	a.True(parsed.Time.Equal(time.Date(2002, 12, 14, 0, 0, 0, 0, time.UTC)))
+	record := marshalRecord{CheckedAt: parsed}
+	a.Nil(yaml.Unmarshal([]byte("checked_at: \"\""), &record))
+	a.False(record.CheckedAt.Valid)
+	a.NotNil(yaml.Unmarshal([]byte("yesterday"), &parsed))
+}
+
+func Test_marshal_YAML(t *testing.T) {
+	a := assert.New(t)
+
+	record := marshalFixture()
+
+	data, err := yaml.Marshal(record)
+	a.Nil(err)
+	a.Equal(`name: nightly
+timestamps:
+    created_at: "2021-07-01T12:30:00.123Z"
+    updated_at: "2021-07-01T13:30:00.123Z"
+    deleted_at: null
+duration:
+    started_at: "2021-07-01T12:30:00.123Z"
+    ended_at: null
+checked_at: "2021-07-01T12:30:00.123Z"
+`, string(data))
+
+	decoded := marshalRecord{Timestamps: TimestampsDocument{Timestamps: Timestamps{DeletedAt: Now()}}}
+	a.Nil(yaml.Unmarshal(data, &decoded))
+	assertMarshalRecord(a, record, decoded)
+
+	a.NotNil(yaml.Unmarshal([]byte("duration:\n  started_at: soon\n"), &decoded))
+}
+
+func Test_marshal_TOML(t *testing.T) {
+	a := assert.New(t)
+
+	record := marshalFixture()
+
+	b := &bytes.Buffer{}
+	a.Nil(toml.NewEncoder(b).Encode(record))
+	a.Contains(b.String(), `timestamps = { created_at = 2021-07-01T12:30:00.123Z, updated_at = 2021-07-01T13:30:00.123Z }`)
+	a.Contains(b.String(), `duration = { started_at = 2021-07-01T12:30:00.123Z }`)
+	a.Contains(b.String(), `checked_at = "2021-07-01T12:30:00.123Z"`)
+
+	decoded := marshalRecord{Timestamps: TimestampsDocument{Timestamps: Timestamps{DeletedAt: Now()}}}
+	_, err := toml.Decode(b.String(), &decoded)
+	a.Nil(err)
+	assertMarshalRecord(a, record, decoded)
+
+	_, err = toml.Decode("checked_at = 2021-07-01T12:30:00Z\n[duration]\nstarted_at = \"2021-07-01T12:30:00Z\"\n", &decoded)
+	a.Nil(err)
+	a.True(decoded.CheckedAt.Valid)
+	a.True(decoded.Duration.StartedAt.Valid)
+	a.False(decoded.Timestamps.CreatedAt.Valid && decoded.Duration.EndedAt.Valid)
+
+	_, err = toml.Decode("[duration]\nstarted_at = 12\n", &decoded)
+	a.NotNil(err)
+}
+
+func Test_marshal_XML(t *testing.T) {
+	a := assert.New(t)
+
+	record := marshalFixture()
+
+	data, err := xml.Marshal(record)
+	a.Nil(err)
+	a.Equal(`<record checked_at="2021-07-01T12:30:00.123Z"><name>nightly</name>`+
+		`<timestamps><created_at>2021-07-01T12:30:00.123Z</created_at><updated_at>2021-07-01T13:30:00.123Z</updated_at></timestamps>`+
+		`<duration><started_at>2021-07-01T12:30:00.123Z</started_at></duration></record>`, string(data))
+
+	decoded := marshalRecord{Timestamps: TimestampsDocument{Timestamps: Timestamps{DeletedAt: Now()}}}
+	a.Nil(xml.Unmarshal(data, &decoded))
+	assertMarshalRecord(a, record, decoded)
+
+	// The naming belongs to each value, decoding needs the same naming as encoding.
+	record.Timestamps.XMLNaming = XMLNaming{CreatedAt: "Created", UpdatedAt: "Updated", DeletedAt: "Deleted", Attributes: true}
+	record.Duration.XMLNaming = XMLNaming{StartedAt: "from", EndedAt: "to", Attributes: true}
+
+	data, err = xml.Marshal(record)
+	a.Nil(err)
+	a.Equal(`<record checked_at="2021-07-01T12:30:00.123Z"><name>nightly</name>`+
+		`<timestamps Created="2021-07-01T12:30:00.123Z" Updated="2021-07-01T13:30:00.123Z"></timestamps>`+
+		`<duration from="2021-07-01T12:30:00.123Z"></duration></record>`, string(data))
+
+	decoded = marshalRecord{}
+	decoded.Timestamps.XMLNaming = record.Timestamps.XMLNaming
+	decoded.Duration.XMLNaming = record.Duration.XMLNaming
+	a.Nil(xml.Unmarshal(data, &decoded))
+	assertMarshalRecord(a, record, decoded)
+
+	a.NotNil(xml.Unmarshal([]byte(`<record><duration from="soon"></duration></record>`), &decoded))
+	a.Nil(xml.Unmarshal([]byte(`<record><duration><note>x</note><to>2021-07-01T12:30:00Z</to></duration></record>`), &decoded))
+	a.True(decoded.Duration.EndedAt.Valid)
+}
+
+func Test_marshal_Embedded(t *testing.T) {
+	a := assert.New(t)
+
+	// Embedding Timestamps promotes no encoding methods, the model keeps its own fields.
+	type model struct {
+		Timestamps `yaml:",inline" xml:"-"`
+		Name       string `yaml:"name" xml:"name"`
+	}
+	record := model{Timestamps: Timestamps{CreatedAt: Now()}, Name: "nightly"}
+
+	data, err := yaml.Marshal(record)
+	a.Nil(err)
+	a.Contains(string(data), "name: nightly")
+
+	data, err = xml.Marshal(record)
+	a.Nil(err)
+	a.Equal("<model><name>nightly</name></model>", string(data))
+}
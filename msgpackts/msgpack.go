@@ -0,0 +1,110 @@
+// Package msgpackts encodes the times of package timestamps for github.com/vmihailenco/msgpack.
+//
+// The types here wrap those of package timestamps and are meant as named fields, a model embedding one of them
+// would be encoded by its methods alone.
+package msgpackts
+
+import (
+	"database/sql"
+	"github.com/hughcube-go/timestamps"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// NullTime is written as the timestamp extension type -1 with nanoseconds, an invalid time as nil.
+type NullTime struct {
+	timestamps.NullTime
+}
+
+func NewNullTime(t sql.NullTime) NullTime {
+	return NullTime{NullTime: timestamps.NewNullTime(t)}
+}
+
+func (t NullTime) EncodeMsgpack(e *msgpack.Encoder) error {
+	if !t.Valid {
+		return e.EncodeNil()
+	}
+	return e.EncodeTime(t.Time)
+}
+
+// DecodeMsgpack also accepts RFC 3339 strings, extension times are decoded in UTC.
+func (t *NullTime) DecodeMsgpack(d *msgpack.Decoder) error {
+	code, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+
+	if code == msgpcode.Nil {
+		*t = NullTime{}
+		return d.DecodeNil()
+	}
+
+	now, err := d.DecodeTime()
+	if err != nil {
+		return err
+	}
+	if !msgpcode.IsString(code) {
+		now = now.UTC()
+	}
+	*t = NewNullTime(sql.NullTime{Time: now, Valid: true})
+	return nil
+}
+
+// Timestamps is written as a map keyed created_at, updated_at and deleted_at.
+type Timestamps struct {
+	timestamps.Timestamps
+}
+
+type timestampsDocument struct {
+	CreatedAt NullTime `msgpack:"created_at"`
+	UpdatedAt NullTime `msgpack:"updated_at"`
+	DeletedAt NullTime `msgpack:"deleted_at"`
+}
+
+func (t Timestamps) EncodeMsgpack(e *msgpack.Encoder) error {
+	return e.Encode(timestampsDocument{
+		CreatedAt: NewNullTime(t.CreatedAt),
+		UpdatedAt: NewNullTime(t.UpdatedAt),
+		DeletedAt: NewNullTime(t.DeletedAt),
+	})
+}
+
+func (t *Timestamps) DecodeMsgpack(d *msgpack.Decoder) error {
+	document := timestampsDocument{}
+	if err := d.Decode(&document); err != nil {
+		return err
+	}
+
+	t.CreatedAt = document.CreatedAt.NullTime.NullTime
+	t.UpdatedAt = document.UpdatedAt.NullTime.NullTime
+	t.DeletedAt = document.DeletedAt.NullTime.NullTime
+	return nil
+}
+
+// Duration is written as a map keyed started_at and ended_at.
+type Duration struct {
+	timestamps.Duration
+}
+
+type durationDocument struct {
+	StartedAt NullTime `msgpack:"started_at"`
+	EndedAt   NullTime `msgpack:"ended_at"`
+}
+
+func (t Duration) EncodeMsgpack(e *msgpack.Encoder) error {
+	return e.Encode(durationDocument{
+		StartedAt: NewNullTime(t.StartedAt),
+		EndedAt:   NewNullTime(t.EndedAt),
+	})
+}
+
+func (t *Duration) DecodeMsgpack(d *msgpack.Decoder) error {
+	document := durationDocument{}
+	if err := d.Decode(&document); err != nil {
+		return err
+	}
+
+	t.StartedAt = document.StartedAt.NullTime.NullTime
+	t.EndedAt = document.EndedAt.NullTime.NullTime
+	return nil
+}
@@ -0,0 +1,76 @@
+package msgpackts
+
+import (
+	"encoding/hex"
+	"github.com/hughcube-go/timestamps"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"testing"
+	"time"
+)
+
+type msgpackRecord struct {
+	Name       string
+	Timestamps Timestamps
+	Duration   Duration
+	CheckedAt  NullTime
+}
+
+func Test_msgpack_NullTime(t *testing.T) {
+	a := assert.New(t)
+
+	seconds := time.Date(2021, 7, 1, 4, 30, 0, 0, time.UTC)
+
+	data, err := msgpack.Marshal([]NullTime{NewNullTime(timestamps.Time(seconds)), {}})
+	a.Nil(err)
+	a.Equal("92d6ff60dd44c8c0", hex.EncodeToString(data))
+
+	// Other consumers see a plain timestamp.
+	var native []*time.Time
+	a.Nil(msgpack.Unmarshal(data, &native))
+	a.True(native[0].Equal(seconds))
+	a.Nil(native[1])
+
+	parsed := NullTime{}
+	data, err = msgpack.Marshal("2021-07-01T12:30:00.5+08:00")
+	a.Nil(err)
+	a.Nil(msgpack.Unmarshal(data, &parsed))
+	a.Equal("2021-07-01T12:30:00.5+08:00", parsed.Format(timestamps.DefaultRFC3339NanoDateLayout))
+	data, err = msgpack.Marshal(true)
+	a.Nil(err)
+	a.NotNil(msgpack.Unmarshal(data, &parsed))
+}
+
+func Test_msgpack_Timestamps(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123000000, time.UTC)
+	record := msgpackRecord{
+		Name: "nightly",
+		Timestamps: Timestamps{timestamps.Timestamps{
+			CreatedAt: timestamps.Time(now),
+			DeletedAt: timestamps.Time(time.Date(1960, 1, 1, 0, 0, 0, 1, time.UTC)),
+		}},
+		Duration:  Duration{timestamps.Duration{StartedAt: timestamps.Time(now)}},
+		CheckedAt: NewNullTime(timestamps.Time(now)),
+	}
+
+	data, err := msgpack.Marshal(record)
+	a.Nil(err)
+
+	decoded := msgpackRecord{Duration: Duration{timestamps.Duration{EndedAt: timestamps.Now()}}}
+	a.Nil(msgpack.Unmarshal(data, &decoded))
+	a.Equal("nightly", decoded.Name)
+	a.True(decoded.Timestamps.CreatedAt.Time.Equal(now))
+	a.Equal(time.UTC, decoded.Timestamps.CreatedAt.Time.Location())
+	a.False(decoded.Timestamps.UpdatedAt.Valid)
+	a.True(decoded.Timestamps.DeletedAt.Time.Equal(record.Timestamps.DeletedAt.Time))
+	a.True(decoded.Duration.StartedAt.Time.Equal(now))
+	a.False(decoded.Duration.EndedAt.Valid)
+	a.True(decoded.CheckedAt.Time.Equal(now))
+
+	var document map[string]interface{}
+	a.Nil(msgpack.Unmarshal(data, &document))
+	a.Nil(document["Duration"].(map[string]interface{})["ended_at"])
+	a.IsType(time.Time{}, document["Timestamps"].(map[string]interface{})["created_at"])
+}
@@ -0,0 +1,267 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRelativeExpression = errors.New("timestamps: invalid relative time expression")
+
+var relativeAbsoluteLayouts = []string{
+	DefaultRFC3339NanoDateLayout,
+	DefaultFineDateWithZoneLayout,
+	DefaultFineDateLayout,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var relativeDays = map[string]int{
+	"yesterday": -1,
+	"today":     0,
+	"tomorrow":  1,
+}
+
+var relativeWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseRelative understands "now", "today", "yesterday 18:00", "-15m", "+2h", "3 days ago", "in 2 weeks",
+// "last monday", "next friday", "start of month" and "end of week", and falls back to the absolute layouts
+// of the Parse family. Day based expressions are evaluated in loc, nil means time.Local.
+func ParseRelative(expr string, ref time.Time, loc *time.Location) (sql.NullTime, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	ref = ref.In(loc)
+
+	words := strings.Fields(strings.ToLower(expr))
+	if 0 >= len(words) {
+		return ZeroTime(), nil
+	}
+
+	if now, ok := parseRelativeWords(words, ref); ok {
+		return validTime(now), nil
+	}
+
+	expr = strings.TrimSpace(expr)
+	for _, layout := range relativeAbsoluteLayouts {
+		if now, err := ParseWithLayoutInLocation(layout, expr, loc); err == nil {
+			return now, nil
+		}
+	}
+	return ZeroTime(), fmt.Errorf("%w: %q", ErrInvalidRelativeExpression, expr)
+}
+
+func parseRelativeWords(words []string, ref time.Time) (time.Time, bool) {
+	switch {
+	case len(words) == 1 && words[0] == "now":
+		return ref, true
+
+	case len(words) <= 2 && isRelativeDay(words[0]):
+		now := addCalendarUnits(floorCalendar(ref, CalendarDay), CalendarDay, relativeDays[words[0]])
+		if len(words) == 1 {
+			return now, true
+		}
+		if clock, ok := parseRelativeClock(words[1]); ok {
+			year, month, day := now.Date()
+			return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location()), true
+		}
+
+	case len(words) == 1 && (words[0][0] == '+' || words[0][0] == '-'):
+		sign := 1
+		if words[0][0] == '-' {
+			sign = -1
+		}
+		return addRelativeAmounts(ref, words[0][1:], sign)
+
+	case len(words) == 3 && words[2] == "ago":
+		if n, err := strconv.Atoi(words[0]); err == nil {
+			return addRelativeUnit(ref, words[1], -n)
+		}
+
+	case len(words) == 3 && words[0] == "in":
+		if n, err := strconv.Atoi(words[1]); err == nil {
+			return addRelativeUnit(ref, words[2], n)
+		}
+
+	case len(words) == 2 && (words[0] == "last" || words[0] == "next" || words[0] == "this"):
+		if weekday, ok := relativeWeekdays[words[1]]; ok {
+			return relativeWeekday(ref, words[0], weekday), true
+		}
+
+	case len(words) == 3 && (words[0] == "start" || words[0] == "beginning" || words[0] == "end") && words[1] == "of":
+		unit, ok := calendarUnitNames[words[2]]
+		if !ok {
+			return time.Time{}, false
+		}
+		if words[0] == "end" {
+			return addCalendarUnits(floorCalendar(ref, unit), unit, 1).Add(-time.Nanosecond), true
+		}
+		return floorCalendar(ref, unit), true
+	}
+
+	return time.Time{}, false
+}
+
+func parseRelativeClock(value string) (time.Time, bool) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return clock, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// addRelativeAmounts applies compact amounts such as "15m" or "1d12h".
+func addRelativeAmounts(now time.Time, value string, sign int) (time.Time, bool) {
+	if 0 >= len(value) {
+		return time.Time{}, false
+	}
+
+	for 0 < len(value) {
+		i := 0
+		for i < len(value) && '0' <= value[i] && value[i] <= '9' {
+			i++
+		}
+		j := i
+		for j < len(value) && !('0' <= value[j] && value[j] <= '9') {
+			j++
+		}
+		if i == 0 || i == j {
+			return time.Time{}, false
+		}
+
+		n, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		var ok bool
+		if now, ok = addRelativeUnit(now, value[i:j], sign*n); !ok {
+			return time.Time{}, false
+		}
+		value = value[j:]
+	}
+	return now, true
+}
+
+func addRelativeUnit(now time.Time, unit string, n int) (time.Time, bool) {
+	switch unit {
+	case "s", "sec", "secs", "second", "seconds":
+		return now.Add(time.Duration(n) * time.Second), true
+	case "m", "min", "mins", "minute", "minutes":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h", "hr", "hrs", "hour", "hours":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d", "day", "days":
+		return now.AddDate(0, 0, n), true
+	case "w", "week", "weeks":
+		return now.AddDate(0, 0, 7*n), true
+	case "mo", "month", "months":
+		return now.AddDate(0, n, 0), true
+	case "y", "year", "years":
+		return now.AddDate(n, 0, 0), true
+	}
+	return time.Time{}, false
+}
+
+func relativeWeekday(ref time.Time, which string, weekday time.Weekday) time.Time {
+	today := floorCalendar(ref, CalendarDay)
+	diff := int(weekday) - int(today.Weekday())
+
+	switch which {
+	case "last":
+		if diff >= 0 {
+			diff -= 7
+		}
+	case "next":
+		if diff <= 0 {
+			diff += 7
+		}
+	default:
+		// "this" refers to the weekday of the current Monday based week.
+		diff = (int(weekday)+6)%7 - (int(today.Weekday())+6)%7
+	}
+	return addCalendarUnits(today, CalendarDay, diff)
+}
+
+func isRelativeDay(word string) bool {
+	_, ok := relativeDays[word]
+	return ok
+}
+
+func isRelativeUnit(word string) bool {
+	_, ok := calendarUnitNames[word]
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// ParseRelativeRange understands "today", "yesterday", "this week", "last month", "next year",
+// "last 7d", "past 24 hours" and "<expr> to <expr>". EndedAt is the exclusive end of the range.
+func ParseRelativeRange(expr string, ref time.Time, loc *time.Location) (Duration, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	ref = ref.In(loc)
+
+	if parts := strings.SplitN(expr, " to ", 2); len(parts) == 2 {
+		return parseRelativeRangeBounds(parts[0], parts[1], ref, loc)
+	}
+	if parts := strings.SplitN(expr, "..", 2); len(parts) == 2 {
+		return parseRelativeRangeBounds(parts[0], parts[1], ref, loc)
+	}
+
+	words := strings.Fields(strings.ToLower(expr))
+	switch {
+	case len(words) == 1 && isRelativeDay(words[0]):
+		start := addCalendarUnits(floorCalendar(ref, CalendarDay), CalendarDay, relativeDays[words[0]])
+		return Duration{StartedAt: validTime(start), EndedAt: validTime(addCalendarUnits(start, CalendarDay, 1))}, nil
+
+	case len(words) == 2 && isRelativeUnit(words[1]) && (words[0] == "this" || words[0] == "last" || words[0] == "next"):
+		offsets := map[string]int{"last": -1, "this": 0, "next": 1}
+		unit := calendarUnitNames[words[1]]
+		start := addCalendarUnits(floorCalendar(ref, unit), unit, offsets[words[0]])
+		return Duration{StartedAt: validTime(start), EndedAt: validTime(addCalendarUnits(start, unit, 1))}, nil
+
+	case len(words) == 2 && (words[0] == "last" || words[0] == "past"):
+		if start, ok := addRelativeAmounts(ref, words[1], -1); ok {
+			return Duration{StartedAt: validTime(start), EndedAt: validTime(ref)}, nil
+		}
+
+	case len(words) == 3 && (words[0] == "last" || words[0] == "past"):
+		if n, err := strconv.Atoi(words[1]); err == nil {
+			if start, ok := addRelativeUnit(ref, words[2], -n); ok {
+				return Duration{StartedAt: validTime(start), EndedAt: validTime(ref)}, nil
+			}
+		}
+	}
+
+	return Duration{}, fmt.Errorf("%w: %q", ErrInvalidRelativeExpression, expr)
+}
+
+func parseRelativeRangeBounds(from string, to string, ref time.Time, loc *time.Location) (Duration, error) {
+	start, err := ParseRelative(from, ref, loc)
+	if err != nil {
+		return Duration{}, err
+	}
+
+	end, err := ParseRelative(to, ref, loc)
+	if err != nil {
+		return Duration{}, err
+	}
+
+	return Duration{StartedAt: start, EndedAt: end}, nil
+}
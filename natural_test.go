@@ -0,0 +1,72 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_natural_ParseRelative(t *testing.T) {
+	a := assert.New(t)
+
+	// Wednesday
+	ref := time.Date(2021, 1, 13, 10, 30, 0, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"now":                 ref,
+		"today":               time.Date(2021, 1, 13, 0, 0, 0, 0, time.UTC),
+		"Yesterday 18:00":     time.Date(2021, 1, 12, 18, 0, 0, 0, time.UTC),
+		"tomorrow":            time.Date(2021, 1, 14, 0, 0, 0, 0, time.UTC),
+		"-15m":                ref.Add(-15 * time.Minute),
+		"+2h":                 ref.Add(2 * time.Hour),
+		"+1d12h":              ref.Add(36 * time.Hour),
+		"3 days ago":          ref.AddDate(0, 0, -3),
+		"in 2 weeks":          ref.AddDate(0, 0, 14),
+		"last monday":         time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC),
+		"last wednesday":      time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+		"next wednesday":      time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		"this sunday":         time.Date(2021, 1, 17, 0, 0, 0, 0, time.UTC),
+		"start of month":      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		"start of week":       time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC),
+		"end of year":         time.Date(2021, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		"2021-02-03 04:05:06": time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC),
+		"2021-02-03":          time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	for expr, expected := range cases {
+		now, err := ParseRelative(expr, ref, time.UTC)
+		a.Nil(err, expr)
+		a.True(now.Valid, expr)
+		a.True(expected.Equal(now.Time), expr)
+	}
+
+	for _, expr := range []string{"soon", "-15x", "3 fortnights ago", "last holiday", "end of decade"} {
+		_, err := ParseRelative(expr, ref, time.UTC)
+		a.NotNil(err, expr)
+	}
+}
+
+func Test_natural_ParseRelativeRange(t *testing.T) {
+	a := assert.New(t)
+
+	ref := time.Date(2021, 1, 13, 10, 30, 0, 0, time.UTC)
+
+	cases := map[string][2]time.Time{
+		"today":         {time.Date(2021, 1, 13, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 14, 0, 0, 0, 0, time.UTC)},
+		"this week":     {time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 18, 0, 0, 0, 0, time.UTC)},
+		"last month":    {time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"last 7d":       {ref.AddDate(0, 0, -7), ref},
+		"past 24 hours": {ref.Add(-24 * time.Hour), ref},
+		"-2h to now":    {ref.Add(-2 * time.Hour), ref},
+	}
+
+	for expr, expected := range cases {
+		duration, err := ParseRelativeRange(expr, ref, time.UTC)
+		a.Nil(err, expr)
+		a.True(expected[0].Equal(duration.StartedAt.Time), expr)
+		a.True(expected[1].Equal(duration.EndedAt.Time), expr)
+	}
+
+	_, err := ParseRelativeRange("now", ref, time.UTC)
+	a.NotNil(err)
+}
@@ -0,0 +1,335 @@
+package timestamps
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLength = errors.New("timestamps: invalid interval or TIME length")
+
+// NullLength is a nullable time.Duration, for PostgreSQL interval and MySQL TIME columns holding GetDurationLength.
+type NullLength struct {
+	Length time.Duration
+	Valid  bool
+}
+
+func NilLength() NullLength {
+	return NullLength{}
+}
+
+func NewLength(length time.Duration) NullLength {
+	return NullLength{Length: length, Valid: true}
+}
+
+// ParseNullLength reads the PostgreSQL interval output styles, "1 day 02:03:04.5", "@ 1 day 2 hours ago",
+// "1 2:03:04.5" and "P-1DT-2H" with a sign on every field, ISO 8601 durations like "P1DT2H" and MySQL TIME values
+// like "26:03:04" or "-838:59:59".
+// Years and months have no fixed length and are rejected, the empty string is NULL.
+func ParseNullLength(value string) (NullLength, error) {
+	value = strings.TrimSpace(value)
+	if 0 >= len(value) {
+		return NilLength(), nil
+	}
+
+	if strings.HasPrefix(value, "P") || strings.HasPrefix(value, "-P") || strings.HasPrefix(value, "+P") {
+		period, err := parseISOPeriodFields(value, true)
+		if err != nil {
+			return NilLength(), err
+		}
+		if length, err := isoPeriodLength(value, period); err != nil {
+			return NilLength(), err
+		} else {
+			return NewLength(length), nil
+		}
+	}
+
+	fields := strings.Fields(value)
+	// The verbose style starts with "@" and negates everything with a trailing "ago".
+	if fields[0] == "@" {
+		fields = fields[1:]
+	}
+	ago := false
+	if 0 < len(fields) && strings.EqualFold(fields[len(fields)-1], "ago") {
+		ago = true
+		fields = fields[:len(fields)-1]
+	}
+	if 0 >= len(fields) {
+		return NilLength(), fmt.Errorf("%w: %q", ErrInvalidLength, value)
+	}
+
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		var part time.Duration
+		var err error
+		switch {
+		case strings.Contains(field, ":"):
+			part, err = parseLengthClock(field)
+		case i+1 < len(fields) && strings.Contains(fields[i+1], ":"):
+			// The sql_standard style and MySQL write days as a bare number before the clock,
+			// a "-" on the days negates a clock without a sign of its own: "-1 2:03:04" is -(1 day 02:03:04).
+			part, err = parseLengthNumber(field, LengthDay)
+			if strings.HasPrefix(field, "-") && !strings.HasPrefix(fields[i+1], "-") && !strings.HasPrefix(fields[i+1], "+") {
+				fields[i+1] = "-" + fields[i+1]
+			}
+		case i+1 < len(fields):
+			i++
+			var unit time.Duration
+			if unit, err = parseLengthUnit(fields[i]); err == nil {
+				part, err = parseLengthNumber(field, unit)
+			}
+		default:
+			part, err = parseLengthNumber(field, time.Second)
+		}
+		if err != nil {
+			return NilLength(), fmt.Errorf("%w: %q: %v", ErrInvalidLength, value, err)
+		}
+
+		if total, err = addLength(total, part); err != nil {
+			return NilLength(), fmt.Errorf("%w: %q: %v", ErrInvalidLength, value, err)
+		}
+	}
+
+	if ago {
+		total = -total
+	}
+	return NewLength(total), nil
+}
+
+func parseLengthUnit(value string) (time.Duration, error) {
+	switch strings.ToLower(value) {
+	case "year", "years", "yr", "yrs", "y", "month", "months", "mon", "mons":
+		return 0, errors.New("years and months have no fixed length")
+	case "week", "weeks", "w":
+		return 7 * LengthDay, nil
+	case "day", "days", "d":
+		return LengthDay, nil
+	case "hour", "hours", "hr", "hrs", "h":
+		return LengthHour, nil
+	case "minute", "minutes", "min", "mins", "m":
+		return time.Minute, nil
+	case "second", "seconds", "sec", "secs", "s":
+		return time.Second, nil
+	case "millisecond", "milliseconds", "msec", "msecs", "ms":
+		return time.Millisecond, nil
+	case "microsecond", "microseconds", "usec", "usecs", "us":
+		return time.Microsecond, nil
+	}
+	return 0, fmt.Errorf("unknown unit %q", value)
+}
+
+// parseLengthNumber reads a signed decimal number of units, like "-1" days or "4.5" secs.
+func parseLengthNumber(value string, unit time.Duration) (time.Duration, error) {
+	negative := false
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		negative = value[0] == '-'
+		value = value[1:]
+	}
+
+	whole, fraction := value, ""
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		whole, fraction = value[:dot], value[dot+1:]
+	}
+	if 0 >= len(whole) || !isLengthDigits(whole) || !isLengthDigits(fraction) {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || n > math.MaxInt64/int64(unit) {
+		return 0, fmt.Errorf("number %q overflows time.Duration", value)
+	}
+	length := time.Duration(n) * unit
+	if 0 < len(fraction) {
+		f, _ := strconv.ParseFloat("0."+fraction, 64)
+		if length, err = addLength(length, time.Duration(math.Round(f*float64(unit)))); err != nil {
+			return 0, err
+		}
+	}
+
+	if negative {
+		return -length, nil
+	}
+	return length, nil
+}
+
+// parseLengthClock reads "[+-]H:MM[:SS[.fffffffff]]", the hours are not limited to a day.
+func parseLengthClock(value string) (time.Duration, error) {
+	negative := false
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		negative = value[0] == '-'
+		value = value[1:]
+	}
+
+	parts := strings.Split(value, ":")
+	if 2 > len(parts) || 3 < len(parts) {
+		return 0, fmt.Errorf("invalid clock %q", value)
+	}
+	if 0 >= len(parts[0]) || !isLengthDigits(parts[0]) {
+		return 0, fmt.Errorf("invalid hours %q", value)
+	}
+	if 2 != len(parts[1]) || !isLengthDigits(parts[1]) || parts[1] > "59" {
+		return 0, fmt.Errorf("invalid minutes %q", value)
+	}
+
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || hours > math.MaxInt64/int64(LengthHour) {
+		return 0, fmt.Errorf("hours %q overflow time.Duration", value)
+	}
+	minutes, _ := strconv.ParseInt(parts[1], 10, 64)
+	length := time.Duration(hours) * LengthHour
+	if length, err = addLength(length, time.Duration(minutes)*time.Minute); err != nil {
+		return 0, err
+	}
+
+	if 3 == len(parts) {
+		seconds, fraction := parts[2], ""
+		if dot := strings.IndexByte(seconds, '.'); dot >= 0 {
+			seconds, fraction = seconds[:dot], seconds[dot+1:]
+		}
+		if 2 != len(seconds) || !isLengthDigits(seconds) || seconds > "59" || 9 < len(fraction) || !isLengthDigits(fraction) {
+			return 0, fmt.Errorf("invalid seconds %q", value)
+		}
+
+		s, _ := strconv.ParseInt(seconds, 10, 64)
+		ns := int64(0)
+		if 0 < len(fraction) {
+			ns, _ = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		}
+		if length, err = addLength(length, time.Duration(s)*time.Second+time.Duration(ns)); err != nil {
+			return 0, err
+		}
+	}
+
+	if negative {
+		return -length, nil
+	}
+	return length, nil
+}
+
+func isLengthDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatNullLength writes "[-]HH:MM:SS[.fffffffff]" with the hours unbounded, which both PostgreSQL interval and
+// MySQL TIME accept. MySQL TIME stops at 838:59:59 and keeps at most microseconds, NULL is the empty string.
+func FormatNullLength(l NullLength) string {
+	if !l.Valid {
+		return ""
+	}
+
+	var b strings.Builder
+	abs := uint64(l.Length)
+	if l.Length < 0 {
+		b.WriteByte('-')
+		abs = -abs
+	}
+
+	hours := abs / uint64(LengthHour)
+	abs -= hours * uint64(LengthHour)
+	minutes := abs / uint64(time.Minute)
+	abs -= minutes * uint64(time.Minute)
+	seconds := abs / uint64(time.Second)
+	fraction := abs - seconds*uint64(time.Second)
+
+	if hours < 10 {
+		b.WriteByte('0')
+	}
+	b.WriteString(strconv.FormatUint(hours, 10))
+	b.WriteString(fmt.Sprintf(":%02d:%02d", minutes, seconds))
+	if fraction > 0 {
+		b.WriteByte('.')
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", fraction), "0"))
+	}
+	return b.String()
+}
+
+func (l NullLength) String() string {
+	return FormatNullLength(l)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (l *NullLength) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*l = NilLength()
+		return nil
+	case []byte:
+		return l.scanString(string(value))
+	case string:
+		return l.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into NullLength", value)
+}
+
+func (l *NullLength) scanString(value string) error {
+	if length, err := ParseNullLength(value); err != nil {
+		return err
+	} else {
+		*l = length
+		return nil
+	}
+}
+
+func (l NullLength) Value() (driver.Value, error) {
+	if !l.Valid {
+		return nil, nil
+	}
+	return FormatNullLength(l), nil
+}
+
+func (l NullLength) MarshalJSON() ([]byte, error) {
+	if !l.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FormatNullLength(l))
+}
+
+func (l *NullLength) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*l = NilLength()
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return l.scanString(value)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// GetNullLength is GetDurationLength as a NullLength, NULL when either side is open.
+func (t *Duration) GetNullLength() NullLength {
+	if !t.StartedAt.Valid || !t.EndedAt.Valid {
+		return NilLength()
+	}
+	return NewLength(time.Duration(t.GetDurationLength()))
+}
+
+// SetNullLength moves EndedAt to StartedAt plus l, NULL opens the end, a Duration without StartedAt is left as is.
+func (t *Duration) SetNullLength(l NullLength) {
+	if !t.StartedAt.Valid {
+		return
+	}
+
+	if l.Valid {
+		t.EndedAt = validTime(t.StartedAt.Time.Add(l.Length))
+	} else {
+		t.EndedAt = NilTime()
+	}
+}
@@ -0,0 +1,171 @@
+package timestamps
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_nulllength_ParseNullLength(t *testing.T) {
+	a := assert.New(t)
+
+	day := 24 * time.Hour
+	for value, expected := range map[string]time.Duration{
+		// PostgreSQL postgres style.
+		"1 day 02:03:04.5":  day + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond,
+		"-1 days +02:03:04": -day + 2*time.Hour + 3*time.Minute + 4*time.Second,
+		"3 days":            3 * day,
+		"00:00:00.000001":   time.Microsecond,
+		"-00:00:01.5":       -1500 * time.Millisecond,
+		// PostgreSQL postgres_verbose and sql_standard styles.
+		"@ 1 day 2 hours 3 mins 4.5 secs ago": -(day + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond),
+		"@ 1.5 days -30 mins":                 36*time.Hour - 30*time.Minute,
+		"1 2:03:04.5":                         day + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond,
+		"-1 2:03:04":                          -(day + 2*time.Hour + 3*time.Minute + 4*time.Second),
+		"-1 +2:03:04":                         -day + 2*time.Hour + 3*time.Minute + 4*time.Second,
+		"+1 -2:03:04":                         day - (2*time.Hour + 3*time.Minute + 4*time.Second),
+		// ISO 8601.
+		"P1DT2H":   day + 2*time.Hour,
+		"-PT1.5S":  -1500 * time.Millisecond,
+		"PT26H3M4": 0,
+		// PostgreSQL iso_8601 style, every field has its own sign.
+		"P-1DT-2H-3M-4.5S": -(day + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond),
+		"PT-2H":            -2 * time.Hour,
+		"P1DT-2H":          day - 2*time.Hour,
+		"-P-1D":            day,
+		"P-1.5D":           -36 * time.Hour,
+		"PT--2H":           0,
+		"PT-H":             0,
+		// MySQL TIME.
+		"26:03:04":        26*time.Hour + 3*time.Minute + 4*time.Second,
+		"-838:59:59":      -(838*time.Hour + 59*time.Minute + 59*time.Second),
+		"12:30":           12*time.Hour + 30*time.Minute,
+		"2 01:00:00":      2*day + time.Hour,
+		"00:00:00.123456": 123456 * time.Microsecond,
+		"42":              42 * time.Second,
+	} {
+		l, err := ParseNullLength(value)
+		if expected == 0 {
+			a.NotNil(err, value)
+			continue
+		}
+		a.Nil(err, value)
+		a.True(l.Valid, value)
+		a.Equal(expected, l.Length, value)
+	}
+
+	l, err := ParseNullLength("")
+	a.Nil(err)
+	a.Equal(NilLength(), l)
+
+	_, err = ParseNullLength("1 year 2 mons")
+	a.True(errors.Is(err, ErrInvalidLength))
+	_, err = ParseNullLength("P1M")
+	a.True(errors.Is(err, ErrNominalISO8601Duration))
+
+	for _, value := range []string{
+		"@",
+		"ago",
+		"1 fortnight",
+		"12:3",
+		"12:30:60",
+		"12:60:00",
+		"1:2:3:4",
+		"12:30:00.1234567891",
+		"1-2",
+		"a:00:00",
+		"3000000 hours",
+		"2562047:47:16.854775808",
+	} {
+		_, err := ParseNullLength(value)
+		a.True(errors.Is(err, ErrInvalidLength), value)
+	}
+}
+
+func Test_nulllength_FormatNullLength(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("", FormatNullLength(NilLength()))
+	a.Equal("00:00:00", FormatNullLength(NewLength(0)))
+	a.Equal("26:03:04.5", FormatNullLength(NewLength(26*time.Hour+3*time.Minute+4500*time.Millisecond)))
+	a.Equal("-838:59:59", NewLength(-(838*time.Hour + 59*time.Minute + 59*time.Second)).String())
+	a.Equal("-00:00:00.000000001", FormatNullLength(NewLength(-1)))
+	a.Equal("-2562047:47:16.854775808", FormatNullLength(NewLength(time.Duration(-1<<63))))
+
+	for _, length := range []time.Duration{0, 1, time.Hour, -36 * time.Hour, 1<<63 - 1, -1<<63 + 1} {
+		back, err := ParseNullLength(FormatNullLength(NewLength(length)))
+		a.Nil(err, length)
+		a.Equal(length, back.Length, length)
+	}
+
+	// Negative lengths written as ISO 8601 come back, and so do the same lengths with a sign on every field.
+	options := LengthOptions{Style: LengthISO8601, Smallest: LengthNanosecond}
+	for value, length := range map[string]time.Duration{
+		"PT-0.000000001S":                  -1,
+		"PT-2H":                            -2 * time.Hour,
+		"P-1DT-2H-3M-4.5S":                 -(26*time.Hour + 3*time.Minute + 4500*time.Millisecond),
+		"P-106751DT-23H-47M-16.854775807S": -1<<63 + 1,
+	} {
+		back, err := ParseNullLength(FormatLength(length, options))
+		a.Nil(err, length)
+		a.Equal(length, back.Length, length)
+
+		back, err = ParseNullLength(value)
+		a.Nil(err, value)
+		a.Equal(length, back.Length, value)
+	}
+}
+
+func Test_nulllength_Scan(t *testing.T) {
+	a := assert.New(t)
+
+	l := NullLength{}
+	a.Nil(l.Scan([]byte("1 day 02:03:04.5")))
+	a.True(l.Valid)
+	value, err := l.Value()
+	a.Nil(err)
+	a.Equal(driver.Value("26:03:04.5"), value)
+
+	a.Nil(l.Scan("26:03:04"))
+	a.Equal(26*time.Hour+3*time.Minute+4*time.Second, l.Length)
+
+	a.Nil(l.Scan(nil))
+	a.False(l.Valid)
+	value, err = l.Value()
+	a.Nil(err)
+	a.Nil(value)
+
+	a.NotNil(l.Scan(42))
+	a.NotNil(l.Scan("1 month"))
+
+	data, err := NewLength(90 * time.Minute).MarshalJSON()
+	a.Nil(err)
+	a.Equal(`"01:30:00"`, string(data))
+	a.Nil(l.UnmarshalJSON([]byte(`"P1DT2H"`)))
+	a.Equal(26*time.Hour, l.Length)
+	a.Nil(l.UnmarshalJSON([]byte("null")))
+	a.False(l.Valid)
+}
+
+func Test_nulllength_Duration(t *testing.T) {
+	a := assert.New(t)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := Duration{StartedAt: Time(start), EndedAt: Time(start.Add(26 * time.Hour))}
+	a.Equal(NewLength(26*time.Hour), d.GetNullLength())
+
+	l := NullLength{}
+	a.Nil(l.Scan("1 day 02:30:00"))
+	d.SetNullLength(l)
+	a.True(d.EndedAt.Time.Equal(start.Add(26*time.Hour + 30*time.Minute)))
+
+	d.SetNullLength(NilLength())
+	a.False(d.EndedAt.Valid)
+	a.Equal(NilLength(), d.GetNullLength())
+
+	open := Duration{}
+	open.SetNullLength(NewLength(time.Hour))
+	a.False(open.EndedAt.Valid)
+}
@@ -0,0 +1,292 @@
+package timestamps
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NullTime is a chainable wrapper around sql.NullTime, operations on an invalid time stay invalid.
+type NullTime struct {
+	sql.NullTime
+}
+
+func NewNullTime(t sql.NullTime) NullTime {
+	return NullTime{NullTime: t}
+}
+
+func (t NullTime) SqlTime() sql.NullTime {
+	return t.NullTime
+}
+
+func (t NullTime) IsValid() bool {
+	return t.Valid
+}
+
+func (t NullTime) apply(f func(now time.Time) time.Time) NullTime {
+	if !t.Valid {
+		return t
+	}
+	return NewNullTime(validTime(f(t.Time)))
+}
+
+func (t NullTime) Format(layout string) string {
+	return FormatWithLayout(layout, t.NullTime)
+}
+
+func (t NullTime) String() string {
+	return Format(t.NullTime)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) In(loc *time.Location) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return now.In(loc)
+	})
+}
+
+func (t NullTime) UTC() NullTime {
+	return t.In(time.UTC)
+}
+
+func (t NullTime) Local() NullTime {
+	return t.In(time.Local)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) StartOfMinute() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, now.Location())
+	})
+}
+
+func (t NullTime) EndOfMinute() NullTime {
+	return t.StartOfMinute().Add(time.Minute - time.Nanosecond)
+}
+
+func (t NullTime) StartOfHour() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+	})
+}
+
+func (t NullTime) EndOfHour() NullTime {
+	return t.StartOfHour().Add(time.Hour - time.Nanosecond)
+}
+
+func (t NullTime) StartOfDay() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return floorCalendar(now, CalendarDay)
+	})
+}
+
+func (t NullTime) EndOfDay() NullTime {
+	return t.StartOfDay().AddDays(1).Add(-time.Nanosecond)
+}
+
+func (t NullTime) StartOfWeek() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return floorCalendar(now, CalendarWeek)
+	})
+}
+
+func (t NullTime) EndOfWeek() NullTime {
+	return t.StartOfWeek().AddDays(7).Add(-time.Nanosecond)
+}
+
+func (t NullTime) StartOfMonth() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return floorCalendar(now, CalendarMonth)
+	})
+}
+
+func (t NullTime) EndOfMonth() NullTime {
+	return t.StartOfMonth().AddMonths(1).Add(-time.Nanosecond)
+}
+
+func (t NullTime) StartOfYear() NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return floorCalendar(now, CalendarYear)
+	})
+}
+
+func (t NullTime) EndOfYear() NullTime {
+	return t.StartOfYear().AddYears(1).Add(-time.Nanosecond)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) Add(d time.Duration) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return now.Add(d)
+	})
+}
+
+func (t NullTime) AddDays(days int) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return now.AddDate(0, 0, days)
+	})
+}
+
+func (t NullTime) AddWeeks(weeks int) NullTime {
+	return t.AddDays(7 * weeks)
+}
+
+// AddMonths overflows like time.AddDate, January 31 plus one month is March 3 (or 2).
+func (t NullTime) AddMonths(months int) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return now.AddDate(0, months, 0)
+	})
+}
+
+// AddMonthsNoOverflow clamps to the last day of the target month, January 31 plus one month is February 28 (or 29).
+func (t NullTime) AddMonthsNoOverflow(months int) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return addMonthsNoOverflow(now, 0, months)
+	})
+}
+
+func (t NullTime) AddYears(years int) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return now.AddDate(years, 0, 0)
+	})
+}
+
+func (t NullTime) AddYearsNoOverflow(years int) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return addMonthsNoOverflow(now, years, 0)
+	})
+}
+
+func addMonthsNoOverflow(now time.Time, years int, months int) time.Time {
+	year, month, day := now.Date()
+	first := time.Date(year+years, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	hour, minute, second := now.Clock()
+	return time.Date(first.Year(), first.Month(), day, hour, minute, second, now.Nanosecond(), now.Location())
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) IsWeekend() bool {
+	return t.Valid && (t.Time.Weekday() == time.Saturday || t.Time.Weekday() == time.Sunday)
+}
+
+func (t NullTime) IsWeekday() bool {
+	return t.Valid && !t.IsWeekend()
+}
+
+func (t NullTime) NextWeekday() NullTime {
+	next := t.AddDays(1)
+	for next.IsWeekend() {
+		next = next.AddDays(1)
+	}
+	return next
+}
+
+func (t NullTime) PreviousWeekday() NullTime {
+	previous := t.AddDays(-1)
+	for previous.IsWeekend() {
+		previous = previous.AddDays(-1)
+	}
+	return previous
+}
+
+// Next returns the start of the next given weekday, never the current day.
+func (t NullTime) Next(weekday time.Weekday) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return relativeWeekday(now, "next", weekday)
+	})
+}
+
+// Previous returns the start of the previous given weekday, never the current day.
+func (t NullTime) Previous(weekday time.Weekday) NullTime {
+	return t.apply(func(now time.Time) time.Time {
+		return relativeWeekday(now, "last", weekday)
+	})
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t NullTime) Before(other NullTime) bool {
+	return t.Valid && other.Valid && t.Time.Before(other.Time)
+}
+
+func (t NullTime) After(other NullTime) bool {
+	return t.Valid && other.Valid && t.Time.After(other.Time)
+}
+
+func (t NullTime) Equal(other NullTime) bool {
+	if !t.Valid || !other.Valid {
+		return t.Valid == other.Valid
+	}
+	return t.Time.Equal(other.Time)
+}
+
+func (t NullTime) Between(start NullTime, end NullTime, inclusive bool) bool {
+	if !t.Valid || !start.Valid || !end.Valid {
+		return false
+	}
+
+	if inclusive {
+		return !t.Time.Before(start.Time) && !t.Time.After(end.Time)
+	}
+	return t.Time.After(start.Time) && t.Time.Before(end.Time)
+}
+
+func (t NullTime) IsPast() bool {
+	return t.Valid && t.Time.Before(time.Now())
+}
+
+func (t NullTime) IsFuture() bool {
+	return t.Valid && t.Time.After(time.Now())
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// diff returns other minus t, the DiffIn* methods report zero when either side is invalid.
+func (t NullTime) diff(other NullTime) time.Duration {
+	if !t.Valid || !other.Valid {
+		return 0
+	}
+	return other.Time.Sub(t.Time)
+}
+
+func (t NullTime) DiffInSeconds(other NullTime) int64 {
+	return int64(t.diff(other) / time.Second)
+}
+
+func (t NullTime) DiffInMinutes(other NullTime) int64 {
+	return int64(t.diff(other) / time.Minute)
+}
+
+func (t NullTime) DiffInHours(other NullTime) int64 {
+	return int64(t.diff(other) / time.Hour)
+}
+
+// DiffInDays counts whole calendar days, so it is not affected by DST changes in between.
+func (t NullTime) DiffInDays(other NullTime) int64 {
+	if !t.Valid || !other.Valid {
+		return 0
+	}
+
+	days := int64(calendarDays(t.Time, other.Time.In(t.Time.Location())))
+	start := t.Time.AddDate(0, 0, int(days))
+	switch {
+	case days > 0 && start.After(other.Time):
+		days--
+	case days < 0 && start.Before(other.Time):
+		days++
+	}
+	return days
+}
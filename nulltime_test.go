@@ -0,0 +1,52 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_nulltime_Chain(t *testing.T) {
+	a := assert.New(t)
+
+	now := NewNullTime(Time(time.Date(2021, 1, 31, 10, 30, 0, 0, time.UTC)))
+
+	a.Equal("2021-01-31 00:00:00", now.StartOfDay().String())
+	a.Equal("2021-01-31 23:59:59.999999999", now.EndOfDay().Format(DefaultFineDateLayout))
+	a.Equal("2021-01-25 00:00:00", now.StartOfWeek().String())
+	a.Equal("2021-02-28 23:59:59", now.AddMonthsNoOverflow(1).EndOfMonth().String())
+	a.Equal("2021-03-03 10:30:00", now.AddMonths(1).String())
+	a.Equal("2021-02-28 10:30:00", now.AddMonthsNoOverflow(1).String())
+	a.Equal("2025-02-28 10:30:00", NewNullTime(Time(time.Date(2024, 2, 29, 10, 30, 0, 0, time.UTC))).AddYearsNoOverflow(1).String())
+	a.Equal("2021-02-01 10:30:00", now.NextWeekday().String())
+	a.Equal("2021-01-29 10:30:00", now.PreviousWeekday().String())
+	a.Equal("2021-02-05 00:00:00", now.Next(time.Friday).String())
+	a.True(now.IsWeekend())
+
+	later := now.AddDays(3).Add(time.Hour)
+	a.Equal(int64(3), now.DiffInDays(later))
+	a.Equal(int64(-3), later.DiffInDays(now))
+	a.Equal(int64(73), now.DiffInHours(later))
+	a.True(now.AddDays(1).Between(now, later, false))
+	a.True(now.Between(now, later, true))
+	a.False(now.Between(now, later, false))
+}
+
+func Test_nulltime_Invalid(t *testing.T) {
+	a := assert.New(t)
+
+	invalid := NewNullTime(NilTime())
+	valid := NewNullTime(Now())
+
+	a.False(invalid.StartOfDay().AddMonthsNoOverflow(1).EndOfMonth().NextWeekday().Valid)
+	a.Equal("", invalid.AddDays(1).String())
+	a.False(invalid.IsWeekend())
+	a.False(invalid.IsWeekday())
+	a.Equal(int64(0), invalid.DiffInDays(valid))
+	a.False(valid.Between(invalid, valid, true))
+
+	timestamps := Timestamps{}
+	a.False(timestamps.GetCreatedAtNullTime().StartOfMonth().Valid)
+	timestamps.TouchCreateTimestamps()
+	a.True(timestamps.GetCreatedAtNullTime().StartOfMonth().Valid)
+}
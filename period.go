@@ -0,0 +1,142 @@
+package timestamps
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownCalendarUnit = errors.New("timestamps: unknown calendar unit")
+
+type PeriodOptions struct {
+	Unit CalendarUnit
+	// Step is the number of units per period, zero means 1.
+	Step int
+	// WeekStart is only used by CalendarWeek, nil starts weeks on Monday like CalendarWeek does everywhere else.
+	WeekStart *time.Weekday
+	// Every switches to fixed length periods counted from StartedAt, Unit and WeekStart are then ignored.
+	Every time.Duration
+	// Location for the calendar boundaries, nil means the location of StartedAt.
+	Location *time.Location
+}
+
+// PeriodIterator walks a Duration as consecutive periods, the first and last ones are clipped to the Duration.
+//
+//	periods := duration.Periods(timestamps.PeriodOptions{Unit: timestamps.CalendarMonth})
+//	for periods.Next() {
+//		period := periods.Period()
+//	}
+//	if err := periods.Err(); err != nil {
+//	}
+type PeriodIterator struct {
+	options  PeriodOptions
+	end      time.Time
+	boundary time.Time
+	period   Duration
+	done     bool
+	err      error
+}
+
+func (t *Duration) Periods(options PeriodOptions) *PeriodIterator {
+	if options.Step <= 0 {
+		options.Step = 1
+	}
+
+	if options.Every <= 0 && (options.Unit < CalendarHour || options.Unit > CalendarYear) {
+		return &PeriodIterator{options: options, done: true, err: fmt.Errorf("%w: %d", ErrUnknownCalendarUnit, options.Unit)}
+	}
+	if !t.StartedAt.Valid || !t.EndedAt.Valid || !t.StartedAt.Time.Before(t.EndedAt.Time) {
+		return &PeriodIterator{options: options, done: true}
+	}
+
+	start := t.StartedAt.Time
+	if options.Location != nil {
+		start = start.In(options.Location)
+	}
+
+	boundary := start
+	if options.Every <= 0 {
+		boundary = floorPeriod(start, options)
+	}
+
+	return &PeriodIterator{
+		options:  options,
+		end:      t.EndedAt.Time,
+		boundary: boundary,
+		period:   Duration{EndedAt: validTime(start)},
+	}
+}
+
+func (it *PeriodIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	start := it.period.EndedAt.Time
+	if !start.Before(it.end) {
+		it.done = true
+		return false
+	}
+
+	next := it.nextBoundary()
+	for !next.After(start) {
+		next = it.nextBoundary()
+	}
+	if next.After(it.end) {
+		next = it.end
+	}
+
+	it.period = Duration{StartedAt: validTime(start), EndedAt: validTime(next)}
+	return true
+}
+
+func (it *PeriodIterator) nextBoundary() time.Time {
+	if it.options.Every > 0 {
+		it.boundary = it.boundary.Add(it.options.Every)
+	} else {
+		unit := it.options.Unit
+		if unit == CalendarWeek {
+			unit = CalendarDay
+			it.boundary = addCalendarUnits(it.boundary, unit, 7*it.options.Step)
+		} else {
+			it.boundary = addCalendarUnits(it.boundary, unit, it.options.Step)
+		}
+	}
+	return it.boundary
+}
+
+func (it *PeriodIterator) Period() Duration {
+	return it.period
+}
+
+// Err is ErrUnknownCalendarUnit when the options name no known unit, Next then returns false at once.
+func (it *PeriodIterator) Err() error {
+	return it.err
+}
+
+func floorPeriod(now time.Time, options PeriodOptions) time.Time {
+	if options.Unit != CalendarWeek || options.WeekStart == nil {
+		return floorCalendar(now, options.Unit)
+	}
+
+	day := floorCalendar(now, CalendarDay)
+	offset := (int(day.Weekday()) - int(*options.WeekStart) + 7) % 7
+	return addCalendarUnits(day, CalendarDay, -offset)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Split collects Periods, an unknown unit gives no periods at all.
+func (t *Duration) Split(options PeriodOptions) []Duration {
+	var periods []Duration
+	for it := t.Periods(options); it.Next(); {
+		periods = append(periods, it.Period())
+	}
+	return periods
+}
+
+// SplitAtMidnight cuts the duration into days of loc (nil means the location of StartedAt).
+func (t *Duration) SplitAtMidnight(loc *time.Location) []Duration {
+	return t.Split(PeriodOptions{Unit: CalendarDay, Location: loc})
+}
@@ -0,0 +1,80 @@
+package timestamps
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func formatPeriods(periods []Duration) []string {
+	var result []string
+	for _, period := range periods {
+		result = append(result, Format(period.StartedAt)+" ~ "+Format(period.EndedAt))
+	}
+	return result
+}
+
+func Test_period_Split(t *testing.T) {
+	a := assert.New(t)
+
+	duration := Duration{
+		StartedAt: Time(time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 3, 2, 6, 0, 0, 0, time.UTC)),
+	}
+
+	a.Equal([]string{
+		"2021-01-15 10:00:00 ~ 2021-02-01 00:00:00",
+		"2021-02-01 00:00:00 ~ 2021-03-01 00:00:00",
+		"2021-03-01 00:00:00 ~ 2021-03-02 06:00:00",
+	}, formatPeriods(duration.Split(PeriodOptions{Unit: CalendarMonth})))
+
+	a.Equal([]string{
+		"2021-01-15 10:00:00 ~ 2021-03-02 06:00:00",
+	}, formatPeriods(duration.Split(PeriodOptions{Unit: CalendarQuarter})))
+
+	sunday := time.Sunday
+	weeks := duration.Split(PeriodOptions{Unit: CalendarWeek, WeekStart: &sunday})
+	a.Equal("2021-01-15 10:00:00 ~ 2021-01-17 00:00:00", formatPeriods(weeks)[0])
+	a.Equal("2021-01-17 00:00:00 ~ 2021-01-24 00:00:00", formatPeriods(weeks)[1])
+	a.Len(weeks, 8)
+
+	weeks = duration.Split(PeriodOptions{Unit: CalendarWeek})
+	a.Equal("2021-01-15 10:00:00 ~ 2021-01-18 00:00:00", formatPeriods(weeks)[0])
+	a.Equal("2021-01-18 00:00:00 ~ 2021-01-25 00:00:00", formatPeriods(weeks)[1])
+	a.True(NewNullTime(weeks[1].StartedAt).StartOfWeek().Time.Equal(weeks[1].StartedAt.Time))
+
+	fortnights := duration.Split(PeriodOptions{Every: 14 * 24 * time.Hour})
+	a.Equal("2021-01-29 10:00:00 ~ 2021-02-12 10:00:00", formatPeriods(fortnights)[1])
+	a.Len(fortnights, 4)
+
+	a.Len((&Duration{StartedAt: duration.StartedAt}).Split(PeriodOptions{Unit: CalendarDay}), 0)
+
+	// An unknown unit stops at once instead of never advancing.
+	periods := duration.Periods(PeriodOptions{Unit: CalendarYear + 1})
+	a.False(periods.Next())
+	a.True(errors.Is(periods.Err(), ErrUnknownCalendarUnit))
+	a.Len(duration.Split(PeriodOptions{Unit: -1}), 0)
+	a.Nil(duration.Periods(PeriodOptions{Unit: CalendarDay}).Err())
+}
+
+func Test_period_SplitAtMidnight(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	shift := Duration{
+		StartedAt: Time(time.Date(2021, 1, 15, 12, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 1, 15, 20, 0, 0, 0, time.UTC)),
+	}
+
+	days := shift.SplitAtMidnight(loc)
+	a.Len(days, 2)
+	a.Equal("2021-01-15 20:00:00 +08:00", FormatWithZone(days[0].StartedAt))
+	a.Equal("2021-01-16 00:00:00 +08:00", FormatWithZone(days[0].EndedAt))
+	a.Equal(int64(4*time.Hour), days[0].GetDurationLength())
+	a.Equal(int64(4*time.Hour), days[1].GetDurationLength())
+
+	a.Len(shift.SplitAtMidnight(time.UTC), 1)
+}
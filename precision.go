@@ -0,0 +1,62 @@
+package timestamps
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Precision normalizes times the way a database column would store them, so that a time compares equal to itself
+// after a round trip. It is applied by Now, Time, the Set*At methods taking a time.Time and the Touch* and
+// LoadDefault* methods, times built from other times such as EndOfDay or parsed ones are left as they are.
+type Precision struct {
+	// Unit is time.Second, time.Millisecond, time.Microsecond or time.Nanosecond, zero leaves times as they are.
+	Unit time.Duration
+	// Round rounds halfway values up like MySQL and PostgreSQL do, instead of truncating.
+	Round bool
+	// StripMonotonic drops the monotonic clock reading of time.Now, which == and reflect.DeepEqual take into account.
+	StripMonotonic bool
+}
+
+var (
+	SecondPrecision      = Precision{Unit: time.Second, StripMonotonic: true}
+	MillisecondPrecision = Precision{Unit: time.Millisecond, StripMonotonic: true}
+	MicrosecondPrecision = Precision{Unit: time.Microsecond, StripMonotonic: true}
+	NanosecondPrecision  = Precision{Unit: time.Nanosecond, StripMonotonic: true}
+
+	// MySQLDatetimePrecision matches DATETIME and TIMESTAMP, MySQLDatetime6Precision DATETIME(6) and TIMESTAMP(6).
+	MySQLDatetimePrecision  = Precision{Unit: time.Second, Round: true, StripMonotonic: true}
+	MySQLDatetime6Precision = Precision{Unit: time.Microsecond, Round: true, StripMonotonic: true}
+	// PostgreSQLPrecision matches timestamp and timestamptz.
+	PostgreSQLPrecision = Precision{Unit: time.Microsecond, Round: true, StripMonotonic: true}
+)
+
+// DefaultPrecision is used where no precision is passed in, it keeps times as they are.
+// A model whose columns differ calls TimeWithPrecision and the WithPrecision variants of the Touch* and LoadDefault*
+// methods.
+var DefaultPrecision = Precision{}
+
+func (p Precision) Apply(now time.Time) time.Time {
+	if p.StripMonotonic {
+		now = now.Round(0)
+	}
+
+	if p.Unit <= time.Nanosecond {
+		return now
+	}
+	// Truncate and Round count from the zero time, zone offsets are whole seconds so units up to a second are not shifted.
+	if p.Round {
+		return now.Round(p.Unit)
+	}
+	return now.Truncate(p.Unit)
+}
+
+func (p Precision) time(now time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  p.Apply(now),
+		Valid: true,
+	}
+}
+
+func (p Precision) now() sql.NullTime {
+	return p.time(time.Now())
+}
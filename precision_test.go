@@ -0,0 +1,83 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_precision_Apply(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.FixedZone("CST", 8*3600))
+
+	a.Equal(now, Precision{}.Apply(now))
+	a.Equal(now, NanosecondPrecision.Apply(now))
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 0, now.Location()), SecondPrecision.Apply(now))
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 123000000, now.Location()), MillisecondPrecision.Apply(now))
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 123457000, now.Location()), MySQLDatetime6Precision.Apply(now))
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 123456000, now.Location()), MicrosecondPrecision.Apply(now))
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 1, 0, now.Location()), MySQLDatetimePrecision.Apply(now.Add(376543211)))
+
+	// The monotonic reading is what makes a fresh time.Now differ from the same instant read back.
+	fresh := time.Now()
+	a.False(fresh == fresh.Round(0))
+	a.True(NanosecondPrecision.Apply(fresh) == fresh.Round(0))
+	a.False(Precision{}.Apply(fresh) == fresh.Round(0))
+}
+
+func Test_precision_Default(t *testing.T) {
+	a := assert.New(t)
+
+	precision := DefaultPrecision
+	defer func() {
+		DefaultPrecision = precision
+	}()
+	DefaultPrecision = PostgreSQLPrecision
+
+	fresh := Now().Time
+	a.Equal(0, fresh.Nanosecond()%1000)
+	a.True(fresh == fresh.Round(0))
+
+	stamps := Timestamps{}
+	stamps.LoadDefaultTimestamps()
+	stamps.TouchDeleteTimestamps()
+	a.Equal(0, stamps.CreatedAt.Time.Nanosecond()%1000)
+	a.Equal(0, stamps.DeletedAt.Time.Nanosecond()%1000)
+
+	now := time.Date(2021, 7, 1, 12, 30, 0, 123456789, time.UTC)
+	stamps.SetUpdatedAt(now)
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 123457000, time.UTC), stamps.UpdatedAt.Time)
+
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 123457000, time.UTC), Time(now).Time)
+	a.Equal(now, TimeWithPrecision(now, NanosecondPrecision).Time)
+	a.Equal(time.Date(2021, 7, 1, 12, 30, 0, 0, time.UTC), TimeWithPrecision(now, SecondPrecision).Time)
+
+	// Times built from other times are not rounded, the end of a day stays on that day.
+	a.Equal("2021-07-01T23:59:59.999999999Z", NewNullTime(Time(now)).EndOfDay().Format(DefaultRFC3339NanoDateLayout))
+	parsed, err := ParseWithLayoutInLocation(DefaultFineDateLayout, "2021-07-01 12:30:00.123456789", time.UTC)
+	a.Nil(err)
+	a.Equal(now, parsed.Time)
+}
+
+func Test_precision_WithPrecision(t *testing.T) {
+	a := assert.New(t)
+
+	stamps := Timestamps{}
+	stamps.TouchCreateTimestampsWithPrecision(SecondPrecision)
+	stamps.TouchUpdateTimestampsWithPrecision(SecondPrecision)
+	a.Equal(0, stamps.CreatedAt.Time.Nanosecond())
+	a.Equal(0, stamps.UpdatedAt.Time.Nanosecond())
+
+	// The precision is not part of the struct, copies compare by their times.
+	copied := stamps
+	a.True(copied == stamps)
+
+	duration := Duration{}
+	duration.LoadDefaultDurationTimestampsWithPrecision(MillisecondPrecision)
+	duration.TouchEndTimestampsWithPrecision(MillisecondPrecision)
+	a.Equal(0, duration.StartedAt.Time.Nanosecond()%int(time.Millisecond))
+	a.Equal(0, duration.EndedAt.Time.Nanosecond()%int(time.Millisecond))
+
+	var _ HasTimestamps = &Timestamps{}
+}
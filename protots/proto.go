@@ -0,0 +1,121 @@
+// Package protots converts the times of package timestamps from and to google.protobuf.Timestamp and Duration.
+package protots
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/hughcube-go/timestamps"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"time"
+)
+
+var ErrTimestampRange = errors.New("timestamps: time is outside the range of google.protobuf.Timestamp")
+var ErrDurationRange = errors.New("timestamps: length is outside the range of google.protobuf.Duration or time.Duration")
+
+// Timestamp converts t, an invalid time becomes nil. Times outside 0001-01-01 to 9999-12-31 UTC are rejected.
+func Timestamp(t sql.NullTime) (*timestamppb.Timestamp, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+
+	ts := timestamppb.New(t.Time)
+	if err := ts.CheckValid(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampRange, err)
+	}
+	return ts, nil
+}
+
+// FromTimestamp converts ts in UTC, nil becomes an invalid time.
+func FromTimestamp(ts *timestamppb.Timestamp) (sql.NullTime, error) {
+	if ts == nil {
+		return timestamps.NilTime(), nil
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		return timestamps.NilTime(), fmt.Errorf("%w: %v", ErrTimestampRange, err)
+	}
+	return sql.NullTime{Time: ts.AsTime(), Valid: true}, nil
+}
+
+// SetTimestamp sets field from ts, e.g. SetTimestamp(&model.CreatedAt, ts). The field is left as is on error.
+func SetTimestamp(field *sql.NullTime, ts *timestamppb.Timestamp) error {
+	if now, err := FromTimestamp(ts); err != nil {
+		return err
+	} else {
+		*field = now
+		return nil
+	}
+}
+
+// DurationTimestamps converts a Duration into its start and end messages, see Timestamp.
+func DurationTimestamps(d timestamps.Duration) (*timestamppb.Timestamp, *timestamppb.Timestamp, error) {
+	start, err := Timestamp(d.StartedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	end, err := Timestamp(d.EndedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+func FromDurationTimestamps(start *timestamppb.Timestamp, end *timestamppb.Timestamp) (timestamps.Duration, error) {
+	d := timestamps.Duration{}
+
+	var err error
+	if d.StartedAt, err = FromTimestamp(start); err != nil {
+		return timestamps.Duration{}, err
+	}
+	if d.EndedAt, err = FromTimestamp(end); err != nil {
+		return timestamps.Duration{}, err
+	}
+	return d, nil
+}
+
+func Duration(length time.Duration) *durationpb.Duration {
+	return durationpb.New(length)
+}
+
+// FromDuration converts d, nil becomes zero. Messages that do not fit time.Duration (about 292 years) are rejected.
+func FromDuration(d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+
+	if err := d.CheckValid(); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDurationRange, err)
+	}
+	seconds, nanos := d.GetSeconds(), int64(d.GetNanos())
+	if seconds > math.MaxInt64/int64(time.Second) || seconds < math.MinInt64/int64(time.Second) {
+		return 0, fmt.Errorf("%w: %ds", ErrDurationRange, seconds)
+	}
+	// CheckValid makes nanos share the sign of seconds, so only the sum can still overflow.
+	length := seconds * int64(time.Second)
+	if (nanos > 0 && length > math.MaxInt64-nanos) || (nanos < 0 && length < math.MinInt64-nanos) {
+		return 0, fmt.Errorf("%w: %ds %dns", ErrDurationRange, seconds, nanos)
+	}
+	return time.Duration(length + nanos), nil
+}
+
+// DurationLength is GetDurationLength of d as a message.
+func DurationLength(d *timestamps.Duration) *durationpb.Duration {
+	return Duration(time.Duration(d.GetDurationLength()))
+}
+
+// SetDurationLength moves EndedAt of d to StartedAt plus length, a Duration without StartedAt is left as is.
+func SetDurationLength(d *timestamps.Duration, length *durationpb.Duration) error {
+	value, err := FromDuration(length)
+	if err != nil {
+		return err
+	}
+
+	if d.StartedAt.Valid {
+		d.EndedAt = sql.NullTime{Time: d.StartedAt.Time.Add(value), Valid: true}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package protots
+
+import (
+	"errors"
+	"github.com/hughcube-go/timestamps"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_proto_Timestamp(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2021, 7, 1, 12, 30, 15, 123456789, time.FixedZone("CST", 8*3600))
+
+	ts, err := Timestamp(timestamps.Time(now))
+	a.Nil(err)
+	a.Equal(now.Unix(), ts.GetSeconds())
+	a.Equal(int32(123456789), ts.GetNanos())
+
+	back, err := FromTimestamp(ts)
+	a.Nil(err)
+	a.True(back.Valid)
+	a.True(back.Time.Equal(now))
+	a.Equal(time.UTC, back.Time.Location())
+
+	ts, err = Timestamp(timestamps.NilTime())
+	a.Nil(err)
+	a.Nil(ts)
+	back, err = FromTimestamp(nil)
+	a.Nil(err)
+	a.False(back.Valid)
+
+	_, err = Timestamp(timestamps.Time(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	a.True(errors.Is(err, ErrTimestampRange))
+	_, err = FromTimestamp(&timestamppb.Timestamp{Seconds: -62135596801})
+	a.True(errors.Is(err, ErrTimestampRange))
+	_, err = FromTimestamp(&timestamppb.Timestamp{Nanos: -1})
+	a.True(errors.Is(err, ErrTimestampRange))
+
+	stamps := timestamps.Timestamps{}
+	a.Nil(SetTimestamp(&stamps.CreatedAt, timestamppb.New(now)))
+	a.True(stamps.CreatedAt.Time.Equal(now))
+	created, err := Timestamp(stamps.CreatedAt)
+	a.Nil(err)
+	a.Equal(now.Unix(), created.GetSeconds())
+	updated, err := Timestamp(stamps.UpdatedAt)
+	a.Nil(err)
+	a.Nil(updated)
+	a.NotNil(SetTimestamp(&stamps.DeletedAt, &timestamppb.Timestamp{Seconds: math.MaxInt64}))
+	a.False(stamps.DeletedAt.Valid)
+}
+
+func Test_proto_DurationTimestamps(t *testing.T) {
+	a := assert.New(t)
+
+	duration := timestamps.Duration{StartedAt: timestamps.Time(time.Date(2021, 7, 1, 9, 0, 0, 0, time.UTC))}
+
+	start, end, err := DurationTimestamps(duration)
+	a.Nil(err)
+	a.Equal(duration.StartedAt.Time.Unix(), start.GetSeconds())
+	a.Nil(end)
+
+	imported, err := FromDurationTimestamps(start, timestamppb.New(time.Date(2021, 7, 1, 18, 0, 0, 0, time.UTC)))
+	a.Nil(err)
+	a.True(imported.StartedAt.Time.Equal(duration.StartedAt.Time))
+	a.Equal(int64(9*time.Hour), imported.GetDurationLength())
+
+	_, err = FromDurationTimestamps(start, &timestamppb.Timestamp{Nanos: 1e9})
+	a.True(errors.Is(err, ErrTimestampRange))
+
+	_, _, err = DurationTimestamps(timestamps.Duration{EndedAt: timestamps.Time(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC))})
+	a.True(errors.Is(err, ErrTimestampRange))
+}
+
+func Test_proto_Duration(t *testing.T) {
+	a := assert.New(t)
+
+	duration := timestamps.Duration{
+		StartedAt: timestamps.Time(time.Date(2021, 7, 1, 9, 0, 0, 0, time.UTC)),
+		EndedAt:   timestamps.Time(time.Date(2021, 7, 1, 10, 30, 0, 500, time.UTC)),
+	}
+
+	d := DurationLength(&duration)
+	a.Equal(int64(5400), d.GetSeconds())
+	a.Equal(int32(500), d.GetNanos())
+
+	a.Nil(SetDurationLength(&duration, durationpb.New(-time.Hour)))
+	a.Equal(int64(-time.Hour), duration.GetDurationLength())
+
+	length, err := FromDuration(nil)
+	a.Nil(err)
+	a.Equal(time.Duration(0), length)
+
+	_, err = FromDuration(&durationpb.Duration{Seconds: 315576000001})
+	a.True(errors.Is(err, ErrDurationRange))
+	_, err = FromDuration(&durationpb.Duration{Seconds: 10000000000})
+	a.True(errors.Is(err, ErrDurationRange))
+	_, err = FromDuration(&durationpb.Duration{Seconds: 1, Nanos: -1})
+	a.True(errors.Is(err, ErrDurationRange))
+	_, err = FromDuration(&durationpb.Duration{Seconds: 9223372036, Nanos: 854775808})
+	a.True(errors.Is(err, ErrDurationRange))
+	_, err = FromDuration(&durationpb.Duration{Seconds: -9223372036, Nanos: -854775809})
+	a.True(errors.Is(err, ErrDurationRange))
+	length, err = FromDuration(&durationpb.Duration{Seconds: 9223372036, Nanos: 854775807})
+	a.Nil(err)
+	a.Equal(time.Duration(math.MaxInt64), length)
+	length, err = FromDuration(&durationpb.Duration{Seconds: -9223372036, Nanos: -854775808})
+	a.Nil(err)
+	a.Equal(time.Duration(math.MinInt64), length)
+	a.True(errors.Is(SetDurationLength(&duration, &durationpb.Duration{Seconds: 10000000000}), ErrDurationRange))
+	a.Equal(int64(-time.Hour), duration.GetDurationLength())
+}
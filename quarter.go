@@ -0,0 +1,225 @@
+package timestamps
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalCalendar decides which months make up a fiscal quarter.
+type FiscalCalendar struct {
+	// StartMonth is the first month of the fiscal year, zero means January.
+	StartMonth time.Month
+	// NamedByEndYear names a fiscal year after the calendar year it ends in,
+	// e.g. the year starting October 2020 is FY2021. Otherwise it is named after the year it starts in.
+	NamedByEndYear bool
+}
+
+var DefaultFiscalCalendar = FiscalCalendar{StartMonth: time.January}
+
+func (c FiscalCalendar) startMonth() time.Month {
+	if c.StartMonth < time.January || c.StartMonth > time.December {
+		return time.January
+	}
+	return c.StartMonth
+}
+
+func (c FiscalCalendar) QuarterOf(ym YearMonth) Quarter {
+	if !ym.Valid {
+		return NilQuarter()
+	}
+
+	start := c.startMonth()
+	offset := (int(ym.Month) - int(start) + 12) % 12
+
+	year := ym.Year
+	if ym.Month < start {
+		year--
+	}
+	if c.NamedByEndYear && start != time.January {
+		year++
+	}
+	return NewQuarter(year, offset/3+1)
+}
+
+func (c FiscalCalendar) QuarterOfTime(now time.Time) Quarter {
+	return c.QuarterOf(YearMonthOf(now))
+}
+
+func (c FiscalCalendar) FirstMonth(q Quarter) YearMonth {
+	if !q.Valid {
+		return NilYearMonth()
+	}
+
+	year := q.Year
+	if c.NamedByEndYear && c.startMonth() != time.January {
+		year--
+	}
+	return NewYearMonth(year, c.startMonth()+time.Month(3*(q.Number-1)))
+}
+
+func (c FiscalCalendar) Months(q Quarter) []YearMonth {
+	if !q.Valid {
+		return nil
+	}
+
+	first := c.FirstMonth(q)
+	return []YearMonth{first, first.Next(), first.AddMonths(2)}
+}
+
+// Duration covers the quarter in loc (nil means time.Local), EndedAt is the first instant of the next quarter.
+func (c FiscalCalendar) Duration(q Quarter, loc *time.Location) Duration {
+	if !q.Valid {
+		return Duration{}
+	}
+
+	first := c.FirstMonth(q)
+	return Duration{
+		StartedAt: first.FirstDay().In(loc),
+		EndedAt:   first.AddMonths(3).FirstDay().In(loc),
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Quarter is a fiscal quarter such as "2021-Q3". Its own methods take the months from DefaultFiscalCalendar,
+// quarters of another calendar go through the FiscalCalendar methods.
+type Quarter struct {
+	Year   int
+	Number int
+	Valid  bool
+}
+
+func NilQuarter() Quarter {
+	return Quarter{}
+}
+
+// NewQuarter normalizes the number, quarter 5 of 2020 is quarter 1 of 2021.
+func NewQuarter(year int, number int) Quarter {
+	index := year*4 + number - 1
+	year, number = index/4, index%4
+	if number < 0 {
+		year, number = year-1, number+4
+	}
+	return Quarter{Year: year, Number: number + 1, Valid: true}
+}
+
+func QuarterOf(now time.Time) Quarter {
+	return DefaultFiscalCalendar.QuarterOfTime(now)
+}
+
+func ParseQuarter(value string) (Quarter, error) {
+	if 0 >= len(value) {
+		return NilQuarter(), nil
+	}
+
+	normalized := strings.Replace(strings.ToUpper(value), "-", "", 1)
+	index := strings.Index(normalized, "Q")
+	if index < 0 || len(normalized) != index+2 {
+		return NilQuarter(), fmt.Errorf("timestamps: cannot parse %q as quarter", value)
+	}
+
+	year, err := strconv.Atoi(normalized[:index])
+	if err != nil {
+		return NilQuarter(), fmt.Errorf("timestamps: cannot parse %q as quarter", value)
+	}
+
+	number := int(normalized[index+1] - '0')
+	if number < 1 || number > 4 {
+		return NilQuarter(), fmt.Errorf("timestamps: cannot parse %q as quarter", value)
+	}
+	return NewQuarter(year, number), nil
+}
+
+func FormatQuarter(q Quarter) string {
+	if !q.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%04d-Q%d", q.Year, q.Number)
+}
+
+func (q Quarter) String() string {
+	return FormatQuarter(q)
+}
+
+func (q Quarter) Next() Quarter {
+	if !q.Valid {
+		return q
+	}
+	return NewQuarter(q.Year, q.Number+1)
+}
+
+func (q Quarter) Prev() Quarter {
+	if !q.Valid {
+		return q
+	}
+	return NewQuarter(q.Year, q.Number-1)
+}
+
+func (q Quarter) FirstMonth() YearMonth {
+	return DefaultFiscalCalendar.FirstMonth(q)
+}
+
+func (q Quarter) Months() []YearMonth {
+	return DefaultFiscalCalendar.Months(q)
+}
+
+func (q Quarter) Duration(loc *time.Location) Duration {
+	return DefaultFiscalCalendar.Duration(q, loc)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (q *Quarter) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*q = NilQuarter()
+		return nil
+	case []byte:
+		return q.scanString(string(value))
+	case string:
+		return q.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into Quarter", value)
+}
+
+func (q *Quarter) scanString(value string) error {
+	if quarter, err := ParseQuarter(value); err != nil {
+		return err
+	} else {
+		*q = quarter
+		return nil
+	}
+}
+
+func (q Quarter) Value() (driver.Value, error) {
+	if !q.Valid {
+		return nil, nil
+	}
+	return FormatQuarter(q), nil
+}
+
+func (q Quarter) MarshalJSON() ([]byte, error) {
+	if !q.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FormatQuarter(q))
+}
+
+func (q *Quarter) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*q = NilQuarter()
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return q.scanString(value)
+}
@@ -0,0 +1,39 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_quarter_FiscalCalendar(t *testing.T) {
+	a := assert.New(t)
+
+	// US federal fiscal year: FY2021 runs from October 2020 to September 2021.
+	federal := FiscalCalendar{StartMonth: time.October, NamedByEndYear: true}
+
+	q := federal.QuarterOfTime(time.Date(2020, 11, 15, 0, 0, 0, 0, time.UTC))
+	a.Equal(NewQuarter(2021, 1), q)
+	a.Equal([]YearMonth{NewYearMonth(2020, 10), NewYearMonth(2020, 11), NewYearMonth(2020, 12)}, federal.Months(q))
+
+	duration := federal.Duration(q, time.UTC)
+	a.Equal("2020-10-01 00:00:00", Format(duration.StartedAt))
+	a.Equal("2021-01-01 00:00:00", Format(duration.EndedAt))
+
+	duration = federal.Duration(NewQuarter(2021, 4), time.UTC)
+	a.Equal("2021-07-01 00:00:00", Format(duration.StartedAt))
+	a.Equal("2021-10-01 00:00:00", Format(duration.EndedAt))
+
+	// Japanese fiscal year: FY2021 runs from April 2021 to March 2022.
+	japan := FiscalCalendar{StartMonth: time.April}
+	q = japan.QuarterOfTime(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC))
+	a.Equal(NewQuarter(2021, 4), q)
+	a.Equal([]YearMonth{NewYearMonth(2022, 1), NewYearMonth(2022, 2), NewYearMonth(2022, 3)}, japan.Months(q))
+
+	// The methods of Quarter keep using DefaultFiscalCalendar.
+	a.Equal([]YearMonth{NewYearMonth(2021, 10), NewYearMonth(2021, 11), NewYearMonth(2021, 12)}, q.Months())
+	a.Equal(NewQuarter(2022, 1), QuarterOf(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	a.Nil(federal.Months(NilQuarter()))
+	a.Equal(Duration{}, federal.Duration(NilQuarter(), time.UTC))
+}
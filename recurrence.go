@@ -0,0 +1,715 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrenceRule = errors.New("timestamps: invalid recurrence rule")
+
+// recurrenceDSTMargin covers the largest DST shift when comparing wall clocks with instants.
+const recurrenceDSTMargin = 3 * time.Hour
+
+// Frequency is the FREQ of a recurrence rule, from the coarsest to the finest.
+type Frequency int
+
+const (
+	FrequencyYearly Frequency = iota
+	FrequencyMonthly
+	FrequencyWeekly
+	FrequencyDaily
+	FrequencyHourly
+	FrequencyMinutely
+	FrequencySecondly
+)
+
+var frequencyNames = map[string]Frequency{
+	"YEARLY":   FrequencyYearly,
+	"MONTHLY":  FrequencyMonthly,
+	"WEEKLY":   FrequencyWeekly,
+	"DAILY":    FrequencyDaily,
+	"HOURLY":   FrequencyHourly,
+	"MINUTELY": FrequencyMinutely,
+	"SECONDLY": FrequencySecondly,
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY entry such as "2TU" or "-1FR", N zero means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RecurrenceRule is an RFC 5545 RRULE, BYWEEKNO is not supported.
+type RecurrenceRule struct {
+	Frequency Frequency
+	// Interval zero means 1.
+	Interval int
+	// Count zero means unlimited, the first occurrence is counted.
+	Count int
+	// Until is inclusive.
+	Until sql.NullTime
+	// WeekStart zero means Sunday, ParseRecurrenceRule defaults it to Monday as RFC 5545 does.
+	WeekStart  time.Weekday
+	ByMonth    []time.Month
+	ByYearDay  []int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+
+	// untilFloating marks an UNTIL without zone, its wall clock is read in the location of the recurrence.
+	untilFloating bool
+}
+
+// ParseRecurrenceRule reads a rule such as "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", an "RRULE:" prefix is allowed.
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	rule := RecurrenceRule{WeekStart: time.Monday}
+
+	value = strings.TrimSpace(value)
+	if 6 <= len(value) && strings.EqualFold(value[:6], "RRULE:") {
+		value = value[6:]
+	}
+
+	hasFrequency := false
+	for _, part := range strings.Split(value, ";") {
+		index := strings.IndexByte(part, '=')
+		if index <= 0 {
+			return rule, fmt.Errorf("%w: %q", ErrInvalidRecurrenceRule, part)
+		}
+		name, values := strings.ToUpper(part[:index]), part[index+1:]
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Frequency, hasFrequency = frequencyNames[strings.ToUpper(values)]
+			if !hasFrequency {
+				err = fmt.Errorf("unknown frequency %q", values)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parseRecurrenceInt(values, 1, 1<<30, false)
+		case "COUNT":
+			rule.Count, err = parseRecurrenceInt(values, 1, 1<<30, false)
+		case "UNTIL":
+			err = rule.parseUntil(values)
+		case "WKST":
+			var ok bool
+			if rule.WeekStart, ok = weekdayCodes[strings.ToUpper(values)]; !ok {
+				err = fmt.Errorf("unknown weekday %q", values)
+			}
+		case "BYMONTH":
+			var months []int
+			if months, err = parseRecurrenceInts(values, 1, 12, false); err == nil {
+				for _, month := range months {
+					rule.ByMonth = append(rule.ByMonth, time.Month(month))
+				}
+			}
+		case "BYYEARDAY":
+			rule.ByYearDay, err = parseRecurrenceInts(values, 1, 366, true)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRecurrenceInts(values, 1, 31, true)
+		case "BYDAY":
+			rule.ByDay, err = parseRecurrenceWeekdays(values)
+		case "BYHOUR":
+			rule.ByHour, err = parseRecurrenceInts(values, 0, 23, false)
+		case "BYMINUTE":
+			rule.ByMinute, err = parseRecurrenceInts(values, 0, 59, false)
+		case "BYSECOND":
+			rule.BySecond, err = parseRecurrenceInts(values, 0, 59, false)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseRecurrenceInts(values, 1, 366, true)
+		default:
+			err = fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return rule, fmt.Errorf("%w: %v", ErrInvalidRecurrenceRule, err)
+		}
+	}
+
+	if !hasFrequency {
+		return rule, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrenceRule)
+	}
+	if 0 < rule.Count && rule.Until.Valid {
+		return rule, fmt.Errorf("%w: COUNT and UNTIL are exclusive", ErrInvalidRecurrenceRule)
+	}
+	return rule, nil
+}
+
+// parseUntil reads a DATE as the end of that day and a DATE-TIME without "Z" as floating.
+func (r *RecurrenceRule) parseUntil(value string) error {
+	until, allDay, err := parseICalTime(icalProperty{name: "UNTIL", value: value}, icalZones{floating: time.UTC})
+	if err != nil {
+		return err
+	}
+	if allDay {
+		until = until.Add(LengthDay - time.Nanosecond)
+	}
+
+	r.Until = validTime(until)
+	r.untilFloating = !strings.HasSuffix(value, "Z")
+	return nil
+}
+
+func parseRecurrenceInt(value string, min int, max int, signed bool) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	magnitude := number
+	if signed && magnitude < 0 {
+		magnitude = -magnitude
+	}
+	if magnitude < min || magnitude > max {
+		return 0, fmt.Errorf("%d is out of range", number)
+	}
+	return number, nil
+}
+
+func parseRecurrenceInts(values string, min int, max int, signed bool) ([]int, error) {
+	var numbers []int
+	for _, value := range strings.Split(values, ",") {
+		if number, err := parseRecurrenceInt(value, min, max, signed); err != nil {
+			return nil, err
+		} else {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+func parseRecurrenceWeekdays(values string) ([]WeekdayNum, error) {
+	var weekdays []WeekdayNum
+	for _, value := range strings.Split(strings.ToUpper(values), ",") {
+		if 2 > len(value) {
+			return nil, fmt.Errorf("unknown weekday %q", value)
+		}
+
+		weekday, ok := weekdayCodes[value[len(value)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", value)
+		}
+
+		n := 0
+		if 2 < len(value) {
+			var err error
+			if n, err = parseRecurrenceInt(value[:len(value)-2], 1, 53, true); err != nil {
+				return nil, err
+			}
+		}
+		weekdays = append(weekdays, WeekdayNum{Weekday: weekday, N: n})
+	}
+	return weekdays, nil
+}
+
+func (r RecurrenceRule) String() string {
+	var parts []string
+	for name, frequency := range frequencyNames {
+		if frequency == r.Frequency {
+			parts = append(parts, "FREQ="+name)
+		}
+	}
+	if 1 < r.Interval {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if 0 < r.Count {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until.Valid {
+		if r.untilFloating {
+			parts = append(parts, "UNTIL="+r.Until.Time.Format(icalDateTimeLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Time.UTC().Format(icalDateTimeLayout)+"Z")
+		}
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+
+	if 0 < len(r.ByMonth) {
+		months := make([]int, 0, len(r.ByMonth))
+		for _, month := range r.ByMonth {
+			months = append(months, int(month))
+		}
+		parts = append(parts, "BYMONTH="+joinRecurrenceInts(months))
+	}
+	if 0 < len(r.ByYearDay) {
+		parts = append(parts, "BYYEARDAY="+joinRecurrenceInts(r.ByYearDay))
+	}
+	if 0 < len(r.ByMonthDay) {
+		parts = append(parts, "BYMONTHDAY="+joinRecurrenceInts(r.ByMonthDay))
+	}
+	if 0 < len(r.ByDay) {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				days = append(days, strconv.Itoa(day.N)+weekdayCode(day.Weekday))
+			} else {
+				days = append(days, weekdayCode(day.Weekday))
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if 0 < len(r.ByHour) {
+		parts = append(parts, "BYHOUR="+joinRecurrenceInts(r.ByHour))
+	}
+	if 0 < len(r.ByMinute) {
+		parts = append(parts, "BYMINUTE="+joinRecurrenceInts(r.ByMinute))
+	}
+	if 0 < len(r.BySecond) {
+		parts = append(parts, "BYSECOND="+joinRecurrenceInts(r.BySecond))
+	}
+	if 0 < len(r.BySetPos) {
+		parts = append(parts, "BYSETPOS="+joinRecurrenceInts(r.BySetPos))
+	}
+	return strings.Join(parts, ";")
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range weekdayCodes {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+func joinRecurrenceInts(numbers []int) string {
+	values := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		values = append(values, strconv.Itoa(number))
+	}
+	return strings.Join(values, ",")
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// occurrences returns the starts after start and before to, rules are evaluated on the wall clock of loc.
+// Without COUNT the periods ending before from are skipped, some earlier starts may still be returned.
+func (r *RecurrenceRule) occurrences(start time.Time, loc *time.Location, from time.Time, to time.Time) []time.Time {
+	if r.Count == 1 {
+		return nil
+	}
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	until, hasUntil := r.Until.Time, r.Until.Valid
+	if hasUntil && r.untilFloating {
+		wall := r.Until.Time
+		until, _ = LocalDate(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc, DefaultCalendarPolicy)
+	}
+
+	wall := wallClock(start)
+
+	// COUNT needs every occurrence from the start, otherwise iterating begins at the period holding from.
+	n := 0
+	if r.Count <= 0 {
+		n = r.periodsUntil(wall, wallClock(from.In(loc).Add(-recurrenceDSTMargin))) / interval * interval
+	}
+
+	var found []time.Time
+	for ; ; n += interval {
+		period := r.periodStart(wall, n)
+		first := time.Date(period.Year(), period.Month(), period.Day(), period.Hour(), period.Minute(), period.Second(), 0, loc)
+		if !first.Add(-recurrenceDSTMargin).Before(to) || (hasUntil && first.Add(-recurrenceDSTMargin).After(until)) {
+			return found
+		}
+
+		for _, candidate := range r.setPositions(r.expand(period, wall)) {
+			now, _ := LocalDate(candidate.Year(), candidate.Month(), candidate.Day(), candidate.Hour(), candidate.Minute(), candidate.Second(), candidate.Nanosecond(), loc, DefaultCalendarPolicy)
+			if !now.After(start) {
+				continue
+			}
+			if (hasUntil && now.After(until)) || !now.Before(to) {
+				return found
+			}
+
+			found = append(found, now)
+			if 0 < r.Count && len(found) >= r.Count-1 {
+				return found
+			}
+		}
+	}
+}
+
+// wallClock drops the zone of now, keeping its wall clock reading.
+func wallClock(now time.Time) time.Time {
+	year, month, day := now.Date()
+	hour, min, sec := now.Clock()
+	return time.Date(year, month, day, hour, min, sec, now.Nanosecond(), time.UTC)
+}
+
+// periodStart returns the wall clock start of the n-th period after the one holding wall.
+func (r *RecurrenceRule) periodStart(wall time.Time, n int) time.Time {
+	year, month, day := wall.Date()
+	hour, min, sec := wall.Clock()
+
+	switch r.Frequency {
+	case FrequencyYearly:
+		return time.Date(year+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case FrequencyMonthly:
+		return time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case FrequencyWeekly:
+		day -= (int(wall.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(year, month, day+7*n, 0, 0, 0, 0, time.UTC)
+	case FrequencyDaily:
+		return time.Date(year, month, day+n, 0, 0, 0, 0, time.UTC)
+	case FrequencyHourly:
+		return time.Date(year, month, day, hour+n, 0, 0, 0, time.UTC)
+	case FrequencyMinutely:
+		return time.Date(year, month, day, hour, min+n, 0, 0, time.UTC)
+	}
+	return time.Date(year, month, day, hour, min, sec+n, 0, time.UTC)
+}
+
+// periodsUntil counts the periods from the one holding wall to the one holding target, zero when target is earlier.
+func (r *RecurrenceRule) periodsUntil(wall time.Time, target time.Time) int {
+	if !target.After(wall) {
+		return 0
+	}
+
+	first, last := r.periodStart(wall, 0), r.periodStart(target, 0)
+	switch r.Frequency {
+	case FrequencyYearly:
+		return last.Year() - first.Year()
+	case FrequencyMonthly:
+		return (last.Year()-first.Year())*12 + int(last.Month()) - int(first.Month())
+	}
+
+	// Wall clocks are in UTC, so the periods are whole numbers of seconds apart.
+	seconds := last.Unix() - first.Unix()
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return int(seconds / (7 * 24 * 3600))
+	case FrequencyDaily:
+		return int(seconds / (24 * 3600))
+	case FrequencyHourly:
+		return int(seconds / 3600)
+	case FrequencyMinutely:
+		return int(seconds / 60)
+	}
+	return int(seconds)
+}
+
+// expand returns the wall clocks of the period matching the rule, in order.
+func (r *RecurrenceRule) expand(period time.Time, wall time.Time) []time.Time {
+	var days []time.Time
+	switch r.Frequency {
+	case FrequencyYearly:
+		for day := period; day.Year() == period.Year(); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	case FrequencyMonthly:
+		for day := period; day.Month() == period.Month(); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	case FrequencyWeekly:
+		for i := 0; i < 7; i++ {
+			days = append(days, period.AddDate(0, 0, i))
+		}
+	default:
+		days = append(days, time.Date(period.Year(), period.Month(), period.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	hours := r.clockValues(r.ByHour, FrequencyHourly, wall.Hour(), period.Hour())
+	minutes := r.clockValues(r.ByMinute, FrequencyMinutely, wall.Minute(), period.Minute())
+	seconds := r.clockValues(r.BySecond, FrequencySecondly, wall.Second(), period.Second())
+
+	var candidates []time.Time
+	for _, day := range days {
+		if !r.matchDay(day, wall) {
+			continue
+		}
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				for _, second := range seconds {
+					candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, wall.Nanosecond(), time.UTC))
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// clockValues expands a BYHOUR, BYMINUTE or BYSECOND list when the frequency is coarser than unit and limits with it otherwise.
+func (r *RecurrenceRule) clockValues(values []int, unit Frequency, first int, current int) []int {
+	if r.Frequency < unit {
+		if 0 < len(values) {
+			return values
+		}
+		return []int{first}
+	}
+
+	if 0 < len(values) && !containsInt(values, current) {
+		return nil
+	}
+	return []int{current}
+}
+
+func (r *RecurrenceRule) matchDay(day time.Time, wall time.Time) bool {
+	if 0 < len(r.ByMonth) {
+		found := false
+		for _, month := range r.ByMonth {
+			found = found || month == day.Month()
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if 0 < len(r.ByYearDay) && !matchOrdinal(r.ByYearDay, day.YearDay(), daysIn(day, FrequencyYearly)) {
+		return false
+	}
+	if 0 < len(r.ByMonthDay) && !matchOrdinal(r.ByMonthDay, day.Day(), daysIn(day, FrequencyMonthly)) {
+		return false
+	}
+	if 0 < len(r.ByDay) && !r.matchWeekday(day) {
+		return false
+	}
+
+	byDate := 0 < len(r.ByYearDay) || 0 < len(r.ByMonthDay) || 0 < len(r.ByDay)
+	switch r.Frequency {
+	case FrequencyYearly:
+		if !byDate {
+			return day.Day() == wall.Day() && (0 < len(r.ByMonth) || day.Month() == wall.Month())
+		}
+	case FrequencyMonthly:
+		if !byDate {
+			return day.Day() == wall.Day()
+		}
+	case FrequencyWeekly:
+		if 0 >= len(r.ByDay) {
+			return day.Weekday() == wall.Weekday()
+		}
+	}
+	return true
+}
+
+// matchWeekday counts an ordinal BYDAY within the month for MONTHLY rules and YEARLY rules with BYMONTH,
+// within the year for other YEARLY rules, and ignores it for finer frequencies.
+func (r *RecurrenceRule) matchWeekday(day time.Time) bool {
+	scope := FrequencyYearly
+	if r.Frequency == FrequencyMonthly || (r.Frequency == FrequencyYearly && 0 < len(r.ByMonth)) {
+		scope = FrequencyMonthly
+	}
+
+	index, total := day.YearDay(), daysIn(day, FrequencyYearly)
+	if scope == FrequencyMonthly {
+		index, total = day.Day(), daysIn(day, FrequencyMonthly)
+	}
+
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.N == 0 || r.Frequency > FrequencyMonthly:
+			return true
+		case weekday.N > 0 && (index-1)/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && (total-index)/7+1 == -weekday.N:
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(day time.Time, scope Frequency) int {
+	if scope == FrequencyYearly {
+		return time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// matchOrdinal matches value against positive ordinals and negative ones counted from total.
+func matchOrdinal(ordinals []int, value int, total int) bool {
+	for _, ordinal := range ordinals {
+		if ordinal == value || (ordinal < 0 && total+ordinal+1 == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) setPositions(candidates []time.Time) []time.Time {
+	if 0 >= len(r.BySetPos) {
+		return candidates
+	}
+
+	var selected []time.Time
+	for i, candidate := range candidates {
+		if matchOrdinal(r.BySetPos, i+1, len(candidates)) {
+			selected = append(selected, candidate)
+		}
+	}
+	return selected
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Recurrence repeats Template by its rules, RDates add occurrences and ExDates remove the ones starting at them.
+// Wall clocks skipped by DST move forward by the gap and repeated ones take the earlier instant, as in RFC 5545.
+type Recurrence struct {
+	// Template is the first occurrence, every occurrence keeps its length.
+	Template Duration
+	Rules    []RecurrenceRule
+	RDates   []sql.NullTime
+	ExDates  []sql.NullTime
+	// Location the rules are evaluated in, nil means the location of Template.StartedAt.
+	Location *time.Location
+	// AllDay keeps the length of occurrences in calendar days of Location, so they end at midnight across DST changes.
+	AllDay bool
+}
+
+// Between returns the occurrences starting within [from, to) in order.
+func (r *Recurrence) Between(from time.Time, to time.Time) []Duration {
+	if !r.Template.StartedAt.Valid || !from.Before(to) {
+		return nil
+	}
+
+	loc := r.Location
+	if loc == nil {
+		loc = r.Template.StartedAt.Time.Location()
+	}
+	start := r.Template.StartedAt.Time.In(loc)
+
+	starts := []time.Time{start}
+	for i := range r.Rules {
+		starts = append(starts, r.Rules[i].occurrences(start, loc, from, to)...)
+	}
+	for _, rdate := range r.RDates {
+		if rdate.Valid {
+			starts = append(starts, rdate.Time.In(loc))
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	days := DateOf(start).DaysUntil(DateOf(r.Template.EndedAt.Time.In(loc)))
+
+	var occurrences []Duration
+	for i, now := range starts {
+		if (0 < i && now.Equal(starts[i-1])) || now.Before(from) || !now.Before(to) || r.excluded(now) {
+			continue
+		}
+
+		occurrence := Duration{StartedAt: validTime(now)}
+		if r.Template.EndedAt.Valid && r.AllDay {
+			occurrence.EndedAt = DateOf(now).AddDays(days).In(loc)
+		} else if r.Template.EndedAt.Valid {
+			occurrence.EndedAt = validTime(now.Add(r.Template.EndedAt.Time.Sub(r.Template.StartedAt.Time)))
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+func (r *Recurrence) excluded(now time.Time) bool {
+	for _, exdate := range r.ExDates {
+		if exdate.Valid && exdate.Time.Equal(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrences repeats the duration by an RRULE, see Recurrence.Between.
+func (t *Duration) Occurrences(rule string, from time.Time, to time.Time) ([]Duration, error) {
+	if parsed, err := ParseRecurrenceRule(rule); err != nil {
+		return nil, err
+	} else {
+		recurrence := Recurrence{Template: *t, Rules: []RecurrenceRule{parsed}}
+		return recurrence.Between(from, to), nil
+	}
+}
+
+// ParseICalRecurrences reads the DTSTART, DTEND, RRULE, RDATE and EXDATE of every VEVENT, see ParseICalEvents for loc.
+func ParseICalRecurrences(text string, loc *time.Location) ([]Recurrence, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	components, err := parseICalComponents(text, "VEVENT")
+	if err != nil {
+		return nil, err
+	}
+	zones, err := icalZonesOf(text, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrences := make([]Recurrence, 0, len(components))
+	for _, component := range components {
+		event, err := icalEventOf(&component, zones)
+		if err != nil {
+			return nil, err
+		}
+
+		recurrence := Recurrence{Template: event.Duration, AllDay: event.AllDay}
+		for _, property := range component.all("RRULE") {
+			if rule, err := ParseRecurrenceRule(property.value); err != nil {
+				return nil, err
+			} else {
+				recurrence.Rules = append(recurrence.Rules, rule)
+			}
+		}
+		if recurrence.RDates, err = parseICalTimeList(component.all("RDATE"), zones); err != nil {
+			return nil, err
+		}
+		if recurrence.ExDates, err = parseICalTimeList(component.all("EXDATE"), zones); err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences, nil
+}
+
+// parseICalTimeList reads comma separated DATE or DATE-TIME values, dates become midnights of the floating location.
+func parseICalTimeList(properties []icalProperty, zones icalZones) ([]sql.NullTime, error) {
+	var times []sql.NullTime
+	for _, property := range properties {
+		for _, value := range strings.Split(property.value, ",") {
+			now, allDay, err := parseICalTime(icalProperty{name: property.name, params: property.params, value: value}, zones)
+			if err != nil {
+				return nil, err
+			}
+			if allDay {
+				times = append(times, DateOf(now).In(zones.floatingLocation()))
+			} else {
+				times = append(times, validTime(now))
+			}
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,261 @@
+package timestamps
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func recurrenceStarts(occurrences []Duration, layout string) []string {
+	starts := make([]string, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		starts = append(starts, occurrence.StartedAt.Time.Format(layout))
+	}
+	return starts
+}
+
+func Test_recurrence_ParseRecurrenceRule(t *testing.T) {
+	a := assert.New(t)
+
+	rule, err := ParseRecurrenceRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU,-1FR;BYSETPOS=1,-1;COUNT=10")
+	a.Nil(err)
+	a.Equal(FrequencyMonthly, rule.Frequency)
+	a.Equal(2, rule.Interval)
+	a.Equal(10, rule.Count)
+	a.Equal([]WeekdayNum{{Weekday: time.Tuesday, N: 2}, {Weekday: time.Friday, N: -1}}, rule.ByDay)
+	a.Equal([]int{-1, 1}, rule.BySetPos)
+	a.Equal(time.Monday, rule.WeekStart)
+	a.Equal("FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=2TU,-1FR;BYSETPOS=-1,1", rule.String())
+
+	rule, err = ParseRecurrenceRule("FREQ=WEEKLY;UNTIL=20211231T235959Z;WKST=SU")
+	a.Nil(err)
+	a.Equal("FREQ=WEEKLY;UNTIL=20211231T235959Z;WKST=SU", rule.String())
+
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20211231",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=YEARLY;BYWEEKNO=20",
+	} {
+		_, err := ParseRecurrenceRule(value)
+		a.True(errors.Is(err, ErrInvalidRecurrenceRule), value)
+	}
+}
+
+func Test_recurrence_Between(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	// Every second Tuesday, 22:00 to 02:00.
+	template := Duration{
+		StartedAt: Time(time.Date(2021, 1, 12, 22, 0, 0, 0, loc)),
+		EndedAt:   Time(time.Date(2021, 1, 13, 2, 0, 0, 0, loc)),
+	}
+	occurrences, err := template.Occurrences("FREQ=MONTHLY;BYDAY=2TU", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2021, 5, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"2021-01-12 22:00 -0500", "2021-02-09 22:00 -0500", "2021-03-09 22:00 -0500", "2021-04-13 22:00 -0400"},
+		recurrenceStarts(occurrences, "2006-01-02 15:04 -0700"))
+	a.Equal(int64(4*time.Hour), occurrences[3].GetDurationLength())
+
+	// Last weekday of the month.
+	template = Duration{StartedAt: Time(time.Date(2021, 1, 29, 9, 0, 0, 0, loc))}
+	occurrences, err = template.Occurrences("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2021, 6, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"2021-01-29", "2021-02-26", "2021-03-31", "2021-04-30", "2021-05-31"}, recurrenceStarts(occurrences, "2006-01-02"))
+	a.False(occurrences[0].EndedAt.Valid)
+
+	// COUNT includes the first occurrence, the 31st is skipped by months without it.
+	template = Duration{StartedAt: Time(time.Date(2021, 1, 31, 9, 0, 0, 0, loc))}
+	occurrences, err = template.Occurrences("FREQ=MONTHLY;COUNT=4", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2022, 1, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"2021-01-31", "2021-03-31", "2021-05-31", "2021-07-31"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	// UNTIL is inclusive, the weekly rule expands BYDAY within weeks.
+	template = Duration{StartedAt: Time(time.Date(2021, 3, 1, 10, 0, 0, 0, loc))}
+	occurrences, err = template.Occurrences("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20210315T150000Z", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2022, 1, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"2021-03-01", "2021-03-04", "2021-03-15"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	// Yearly on the fourth Thursday of November.
+	template = Duration{StartedAt: Time(time.Date(2021, 11, 25, 12, 0, 0, 0, loc))}
+	occurrences, err = template.Occurrences("FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 1, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"2021-11-25", "2022-11-24", "2023-11-23"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	// BYHOUR and BYMINUTE expand a daily rule.
+	template = Duration{StartedAt: Time(time.Date(2021, 6, 1, 8, 0, 0, 0, loc))}
+	occurrences, err = template.Occurrences("FREQ=DAILY;BYHOUR=8,20;BYMINUTE=0,30;COUNT=5", time.Date(2021, 1, 1, 0, 0, 0, 0, loc), time.Date(2022, 1, 1, 0, 0, 0, 0, loc))
+	a.Nil(err)
+	a.Equal([]string{"06-01 08:00", "06-01 08:30", "06-01 20:00", "06-01 20:30", "06-02 08:00"}, recurrenceStarts(occurrences, "01-02 15:04"))
+
+	_, err = template.Occurrences("FREQ=NEVER", time.Now(), time.Now())
+	a.True(errors.Is(err, ErrInvalidRecurrenceRule))
+}
+
+func Test_recurrence_DST(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	// 02:30 does not exist on 2021-03-14 and moves forward by the gap, the wall clock is kept afterwards.
+	recurrence := Recurrence{
+		Template: Duration{StartedAt: Time(time.Date(2021, 3, 13, 2, 30, 0, 0, loc))},
+		Rules:    []RecurrenceRule{{Frequency: FrequencyDaily}},
+	}
+	occurrences := recurrence.Between(time.Date(2021, 3, 13, 0, 0, 0, 0, loc), time.Date(2021, 3, 16, 0, 0, 0, 0, loc))
+	a.Equal([]string{"03-13 02:30 -0500", "03-14 03:30 -0400", "03-15 02:30 -0400"}, recurrenceStarts(occurrences, "01-02 15:04 -0700"))
+
+	// 01:30 happens twice on 2021-11-07, the earlier instant is taken.
+	recurrence.Template = Duration{StartedAt: Time(time.Date(2021, 11, 6, 1, 30, 0, 0, loc))}
+	occurrences = recurrence.Between(time.Date(2021, 11, 6, 0, 0, 0, 0, loc), time.Date(2021, 11, 8, 0, 0, 0, 0, loc))
+	a.Equal([]string{"11-06 01:30 -0400", "11-07 01:30 -0400"}, recurrenceStarts(occurrences, "01-02 15:04 -0700"))
+
+	// Evaluated in another location the wall clock follows that location.
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+	recurrence.Template = Duration{StartedAt: Time(time.Date(2021, 3, 13, 9, 0, 0, 0, shanghai))}
+	recurrence.Location = shanghai
+	occurrences = recurrence.Between(time.Date(2021, 3, 13, 0, 0, 0, 0, shanghai), time.Date(2021, 3, 16, 0, 0, 0, 0, shanghai))
+	a.Equal([]string{"03-13 09:00", "03-14 09:00", "03-15 09:00"}, recurrenceStarts(occurrences, "01-02 15:04"))
+}
+
+func Test_recurrence_LongRunning(t *testing.T) {
+	a := assert.New(t)
+
+	// Rules started long ago are evaluated from the period holding from, keeping INTERVAL aligned with the start.
+	template := Duration{StartedAt: Time(time.Date(1990, 1, 1, 9, 0, 0, 0, time.UTC))}
+	occurrences, err := template.Occurrences("FREQ=DAILY;INTERVAL=3", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 8, 0, 0, 0, 0, time.UTC))
+	a.Nil(err)
+	a.Equal([]string{"2021-06-02", "2021-06-05"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	occurrences, err = template.Occurrences("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 22, 0, 0, 0, 0, time.UTC))
+	a.Nil(err)
+	a.Equal([]string{"2021-06-07", "2021-06-11", "2021-06-21"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	occurrences, err = template.Occurrences("FREQ=MINUTELY;INTERVAL=7", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 0, 15, 0, 0, time.UTC))
+	a.Nil(err)
+	a.Equal([]string{"00:03", "00:10"}, recurrenceStarts(occurrences, "15:04"))
+
+	occurrences, err = template.Occurrences("FREQ=MONTHLY;INTERVAL=5", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.Nil(err)
+	a.Equal([]string{"2021-04-01", "2021-09-01"}, recurrenceStarts(occurrences, "2006-01-02"))
+
+	// Skipping periods gives the same occurrences as walking from the start.
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+	from, to := time.Date(2021, 3, 10, 0, 0, 0, 0, loc), time.Date(2021, 3, 20, 0, 0, 0, 0, loc)
+	for _, rule := range []string{
+		"FREQ=YEARLY;BYMONTH=3;BYDAY=MO,TU",
+		"FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=12,-14",
+		"FREQ=WEEKLY;INTERVAL=3;BYDAY=SU,WE;WKST=SU",
+		"FREQ=DAILY;INTERVAL=4;BYHOUR=1,2,3",
+		"FREQ=HOURLY;INTERVAL=5",
+	} {
+		template := Duration{StartedAt: Time(time.Date(2020, 11, 1, 1, 30, 0, 0, loc))}
+		all, err := template.Occurrences(rule, template.StartedAt.Time, to)
+		a.Nil(err)
+		var expected []Duration
+		for _, occurrence := range all {
+			if !occurrence.StartedAt.Time.Before(from) {
+				expected = append(expected, occurrence)
+			}
+		}
+		occurrences, err := template.Occurrences(rule, from, to)
+		a.Nil(err)
+		a.NotEmpty(occurrences, rule)
+		a.Equal(recurrenceStarts(expected, time.RFC3339), recurrenceStarts(occurrences, time.RFC3339), rule)
+	}
+}
+
+func Test_recurrence_AllDay(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	recurrence := Recurrence{
+		Template: Duration{
+			StartedAt: Time(time.Date(2021, 3, 12, 0, 0, 0, 0, loc)),
+			EndedAt:   Time(time.Date(2021, 3, 13, 0, 0, 0, 0, loc)),
+		},
+		Rules:  []RecurrenceRule{{Frequency: FrequencyDaily}},
+		AllDay: true,
+	}
+
+	occurrences := recurrence.Between(time.Date(2021, 3, 13, 0, 0, 0, 0, loc), time.Date(2021, 3, 16, 0, 0, 0, 0, loc))
+	a.Equal(3, len(occurrences))
+	for _, occurrence := range occurrences {
+		a.Equal("00:00", occurrence.EndedAt.Time.Format("15:04"))
+	}
+	a.Equal(int64(23*time.Hour), occurrences[1].GetDurationLength())
+
+	// Timed occurrences keep the absolute length.
+	recurrence.AllDay = false
+	occurrences = recurrence.Between(time.Date(2021, 3, 13, 0, 0, 0, 0, loc), time.Date(2021, 3, 16, 0, 0, 0, 0, loc))
+	a.Equal("03-15 01:00", occurrences[1].EndedAt.Time.Format("01-02 15:04"))
+}
+
+func Test_recurrence_Dates(t *testing.T) {
+	a := assert.New(t)
+
+	recurrence := Recurrence{
+		Template: Duration{
+			StartedAt: Time(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)),
+			EndedAt:   Time(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+		},
+		Rules:   []RecurrenceRule{{Frequency: FrequencyDaily, Count: 4}},
+		RDates:  []sql.NullTime{Time(time.Date(2021, 6, 3, 15, 0, 0, 0, time.UTC)), Time(time.Date(2021, 6, 2, 9, 0, 0, 0, time.UTC))},
+		ExDates: []sql.NullTime{Time(time.Date(2021, 6, 3, 9, 0, 0, 0, time.UTC))},
+	}
+
+	occurrences := recurrence.Between(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC))
+	a.Equal([]string{"06-02 09:00", "06-03 15:00", "06-04 09:00"}, recurrenceStarts(occurrences, "01-02 15:04"))
+	a.Equal(int64(time.Hour), occurrences[1].GetDurationLength())
+
+	a.Nil(recurrence.Between(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_recurrence_ParseICalRecurrences(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	text := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=America/New_York:20210105T220000\r\n" +
+		"DTEND;TZID=America/New_York:20210106T000000\r\n" +
+		"RRULE:FREQ=WEEKLY;COUNT=4\r\n" +
+		"EXDATE;TZID=America/New_York:20210112T220000,20210119T220000\r\n" +
+		"RDATE:20210201T030000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20211001\r\n" +
+		"RRULE:FREQ=YEARLY\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	recurrences, err := ParseICalRecurrences(text, loc)
+	a.Nil(err)
+	a.Equal(2, len(recurrences))
+
+	occurrences := recurrences[0].Between(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.Equal([]string{"2021-01-05T22:00", "2021-01-26T22:00", "2021-01-31T22:00"}, recurrenceStarts(occurrences, "2006-01-02T15:04"))
+
+	occurrences = recurrences[1].Between(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.Equal([]string{"2021-10-01 00:00 +0800", "2022-10-01 00:00 +0800", "2023-10-01 00:00 +0800"}, recurrenceStarts(occurrences, "2006-01-02 15:04 -0700"))
+	a.Equal(int64(24*time.Hour), occurrences[0].GetDurationLength())
+	a.True(recurrences[1].AllDay)
+
+	_, err = ParseICalRecurrences("BEGIN:VEVENT\r\nDTSTART:20210101T000000Z\r\nRRULE:FREQ=SOMETIMES\r\nEND:VEVENT\r\n", loc)
+	a.True(errors.Is(err, ErrInvalidRecurrenceRule))
+}
@@ -0,0 +1,200 @@
+package timestamps
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type RelativeUnit int
+
+const (
+	RelativeMinute RelativeUnit = iota
+	RelativeHour
+	RelativeDay
+)
+
+type RelativeLocale interface {
+	JustNow() string
+	Yesterday() string
+	Tomorrow() string
+	Ago(unit RelativeUnit, n int64) string
+	Later(unit RelativeUnit, n int64) string
+}
+
+type RelativeOptions struct {
+	// Differences below JustNow are rendered as "just now".
+	JustNow time.Duration
+	// Differences below Minutes are rendered in minutes, below Hours in hours.
+	Minutes time.Duration
+	Hours   time.Duration
+	// Differences below Days are rendered in days ("yesterday" / "tomorrow" for one calendar day),
+	// anything further away falls back to DateLayout.
+	Days       time.Duration
+	DateLayout string
+	Locale     RelativeLocale
+}
+
+var DefaultRelativeOptions = RelativeOptions{
+	JustNow:    time.Minute,
+	Minutes:    time.Hour,
+	Hours:      24 * time.Hour,
+	Days:       7 * 24 * time.Hour,
+	DateLayout: DefaultDateLayout,
+	Locale:     EnglishRelativeLocale,
+}
+
+func FormatRelative(t sql.NullTime, ref time.Time) string {
+	return FormatRelativeWithOptions(t, ref, DefaultRelativeOptions)
+}
+
+func FormatRelativeWithOptions(t sql.NullTime, ref time.Time, options RelativeOptions) string {
+	if !t.Valid {
+		return ""
+	}
+
+	locale := options.Locale
+	if locale == nil {
+		locale = EnglishRelativeLocale
+	}
+
+	diff := ref.Sub(t.Time)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+
+	if diff < options.JustNow {
+		return locale.JustNow()
+	}
+
+	if diff < options.Minutes {
+		return relativeWord(locale, future, RelativeMinute, int64(diff/time.Minute))
+	}
+
+	if diff < options.Hours {
+		return relativeWord(locale, future, RelativeHour, int64(diff/time.Hour))
+	}
+
+	if diff < options.Days {
+		days := calendarDays(t.Time.In(ref.Location()), ref)
+		if days < 0 {
+			days = -days
+		}
+
+		switch {
+		case days == 1 && future:
+			return locale.Tomorrow()
+		case days == 1:
+			return locale.Yesterday()
+		case days > 1:
+			return relativeWord(locale, future, RelativeDay, int64(days))
+		}
+	}
+
+	layout := options.DateLayout
+	if 0 >= len(layout) {
+		layout = DefaultDateLayout
+	}
+	return FormatWithLayout(layout, validTime(t.Time.In(ref.Location())))
+}
+
+func relativeWord(locale RelativeLocale, future bool, unit RelativeUnit, n int64) string {
+	if n < 1 {
+		n = 1
+	}
+
+	if future {
+		return locale.Later(unit, n)
+	}
+	return locale.Ago(unit, n)
+}
+
+// calendarDays returns the number of midnights between a and b, ignoring the clock time.
+func calendarDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var EnglishRelativeLocale RelativeLocale = englishRelativeLocale{}
+
+type englishRelativeLocale struct{}
+
+func (englishRelativeLocale) JustNow() string {
+	return "just now"
+}
+
+func (englishRelativeLocale) Yesterday() string {
+	return "yesterday"
+}
+
+func (englishRelativeLocale) Tomorrow() string {
+	return "tomorrow"
+}
+
+func (l englishRelativeLocale) Ago(unit RelativeUnit, n int64) string {
+	return l.amount(unit, n) + " ago"
+}
+
+func (l englishRelativeLocale) Later(unit RelativeUnit, n int64) string {
+	return "in " + l.amount(unit, n)
+}
+
+func (englishRelativeLocale) amount(unit RelativeUnit, n int64) string {
+	name := "minute"
+	switch unit {
+	case RelativeHour:
+		name = "hour"
+	case RelativeDay:
+		name = "day"
+	}
+
+	if n != 1 {
+		name += "s"
+	}
+	return fmt.Sprintf("%d %s", n, name)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+var ChineseRelativeLocale RelativeLocale = chineseRelativeLocale{}
+
+type chineseRelativeLocale struct{}
+
+func (chineseRelativeLocale) JustNow() string {
+	return "刚刚"
+}
+
+func (chineseRelativeLocale) Yesterday() string {
+	return "昨天"
+}
+
+func (chineseRelativeLocale) Tomorrow() string {
+	return "明天"
+}
+
+func (l chineseRelativeLocale) Ago(unit RelativeUnit, n int64) string {
+	return l.amount(unit, n) + "前"
+}
+
+func (l chineseRelativeLocale) Later(unit RelativeUnit, n int64) string {
+	return l.amount(unit, n) + "后"
+}
+
+func (chineseRelativeLocale) amount(unit RelativeUnit, n int64) string {
+	switch unit {
+	case RelativeHour:
+		return fmt.Sprintf("%d小时", n)
+	case RelativeDay:
+		return fmt.Sprintf("%d天", n)
+	}
+	return fmt.Sprintf("%d分钟", n)
+}
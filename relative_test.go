@@ -0,0 +1,45 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_relative_FormatRelative(t *testing.T) {
+	a := assert.New(t)
+
+	ref := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := map[time.Duration]string{
+		-10 * time.Second: "just now",
+		-time.Minute:      "1 minute ago",
+		-5 * time.Minute:  "5 minutes ago",
+		2 * time.Hour:     "in 2 hours",
+		-23 * time.Hour:   "23 hours ago",
+		-30 * time.Hour:   "yesterday",
+		30 * time.Hour:    "tomorrow",
+		-72 * time.Hour:   "3 days ago",
+		-240 * time.Hour:  "2020-12-31 12:00:00",
+	}
+
+	for diff, expected := range cases {
+		a.Equal(expected, FormatRelative(Time(ref.Add(diff)), ref))
+	}
+
+	a.Equal("", FormatRelative(NilTime(), ref))
+}
+
+func Test_relative_ChineseLocale(t *testing.T) {
+	a := assert.New(t)
+
+	ref := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	options := DefaultRelativeOptions
+	options.Locale = ChineseRelativeLocale
+
+	a.Equal("刚刚", FormatRelativeWithOptions(Time(ref), ref, options))
+	a.Equal("5分钟前", FormatRelativeWithOptions(Time(ref.Add(-5*time.Minute)), ref, options))
+	a.Equal("3小时后", FormatRelativeWithOptions(Time(ref.Add(3*time.Hour)), ref, options))
+	a.Equal("昨天", FormatRelativeWithOptions(Time(ref.Add(-30*time.Hour)), ref, options))
+	a.Equal("3天前", FormatRelativeWithOptions(Time(ref.Add(-72*time.Hour)), ref, options))
+}
@@ -14,11 +14,26 @@ const DefaultFineDateWithZoneLayout = "2006-01-02 15:04:05.999999999 Z07:00"
 const DefaultRFC3339DateLayout = time.RFC3339
 const DefaultRFC3339NanoDateLayout = time.RFC3339Nano
 
+const DefaultDateOnlyLayout = "2006-01-02"
+const DefaultTimeOfDayLayout = "15:04:05"
+const DefaultFineTimeOfDayLayout = "15:04:05.999999999"
+
 func NilTime() sql.NullTime {
 	return sql.NullTime{}
 }
 
+// Time returns now as a valid time after DefaultPrecision, see TimeWithPrecision.
 func Time(now time.Time) sql.NullTime {
+	return DefaultPrecision.time(now)
+}
+
+// TimeWithPrecision returns now as a valid time after p, for a column that differs from DefaultPrecision.
+func TimeWithPrecision(now time.Time, p Precision) sql.NullTime {
+	return p.time(now)
+}
+
+// validTime returns now as a valid time as it is, for times built from other times.
+func validTime(now time.Time) sql.NullTime {
 	return sql.NullTime{
 		Time:  now,
 		Valid: true,
@@ -32,6 +47,7 @@ func ZeroTime() sql.NullTime {
 	}
 }
 
+// Now applies DefaultPrecision.
 func Now() sql.NullTime {
 	return Time(time.Now())
 }
@@ -40,22 +56,49 @@ func Now() sql.NullTime {
 ///////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////
 func ParseWithLayout(layout string, date string) (sql.NullTime, error) {
+	return ParseWithLayoutInLocation(layout, date, time.Local)
+}
+
+func ParseWithLayoutInLocation(layout string, date string, loc *time.Location) (sql.NullTime, error) {
 	if 0 >= len(date) {
 		return ZeroTime(), nil
 	}
 
-	if now, err := time.ParseInLocation(layout, date, time.Local); err == nil {
-		return Time(now), nil
+	if now, ok := parseFast(layout, date, loc); ok {
+		return validTime(now), nil
+	}
+
+	layout, err := ResolveLayout(layout)
+	if err != nil {
+		return ZeroTime(), err
+	}
+
+	if now, err := time.ParseInLocation(layout, date, loc); err == nil {
+		return validTime(now), nil
 	} else {
 		return ZeroTime(), err
 	}
 }
 
+// FormatWithLayout passes a layout that cannot be translated to time.Format as it is, use FormatWithLayoutE to see the error.
 func FormatWithLayout(layout string, t sql.NullTime) string {
-	if t.Valid {
-		return t.Time.Format(layout)
+	if !t.Valid {
+		return ""
+	}
+
+	b := [64]byte{}
+	return string(AppendFormatWithLayout(b[:0], layout, t))
+}
+
+func FormatWithLayoutE(layout string, t sql.NullTime) (string, error) {
+	if !t.Valid {
+		return "", nil
+	}
+
+	if _, err := ResolveLayout(layout); err != nil {
+		return "", err
 	}
-	return ""
+	return FormatWithLayout(layout, t), nil
 }
 
 /////////////////////////////////////////////////////////
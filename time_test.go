@@ -16,6 +16,9 @@ func Test_time_DefaultDateLayout(t *testing.T) {
 		DefaultFineDateWithZoneLayout,
 		DefaultRFC3339DateLayout,
 		DefaultRFC3339NanoDateLayout,
+		DefaultDateOnlyLayout,
+		DefaultTimeOfDayLayout,
+		DefaultFineTimeOfDayLayout,
 	}
 
 	for _, layout := range layouts {
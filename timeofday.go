@@ -0,0 +1,196 @@
+package timestamps
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NullTimeOfDay is a wall clock time without date or zone, for TIME columns.
+type NullTimeOfDay struct {
+	SinceMidnight time.Duration
+	Valid         bool
+}
+
+func NilTimeOfDay() NullTimeOfDay {
+	return NullTimeOfDay{}
+}
+
+func TimeOfDay(hour int, minute int, second int, nanosecond int) NullTimeOfDay {
+	return NewTimeOfDay(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + time.Duration(nanosecond))
+}
+
+// NewTimeOfDay wraps the given offset from midnight around the clock.
+func NewTimeOfDay(sinceMidnight time.Duration) NullTimeOfDay {
+	sinceMidnight %= LengthDay
+	if sinceMidnight < 0 {
+		sinceMidnight += LengthDay
+	}
+	return NullTimeOfDay{SinceMidnight: sinceMidnight, Valid: true}
+}
+
+func TimeOfDayOf(now time.Time) NullTimeOfDay {
+	hour, minute, second := now.Clock()
+	return TimeOfDay(hour, minute, second, now.Nanosecond())
+}
+
+func ParseTimeOfDay(value string) (NullTimeOfDay, error) {
+	if 0 >= len(value) {
+		return NilTimeOfDay(), nil
+	}
+
+	var err error
+	for _, layout := range []string{DefaultFineTimeOfDayLayout, "15:04"} {
+		var now time.Time
+		if now, err = time.Parse(layout, value); err == nil {
+			return TimeOfDayOf(now), nil
+		}
+	}
+	return NilTimeOfDay(), err
+}
+
+func FormatTimeOfDay(t NullTimeOfDay) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.clock().Format(DefaultTimeOfDayLayout)
+}
+
+func FormatFineTimeOfDay(t NullTimeOfDay) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.clock().Format(DefaultFineTimeOfDayLayout)
+}
+
+func (t NullTimeOfDay) String() string {
+	return FormatFineTimeOfDay(t)
+}
+
+func (t NullTimeOfDay) clock() time.Time {
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(t.SinceMidnight)
+}
+
+func (t NullTimeOfDay) Hour() int {
+	return t.clock().Hour()
+}
+
+func (t NullTimeOfDay) Minute() int {
+	return t.clock().Minute()
+}
+
+func (t NullTimeOfDay) Second() int {
+	return t.clock().Second()
+}
+
+func (t NullTimeOfDay) Nanosecond() int {
+	return t.clock().Nanosecond()
+}
+
+// On combines the wall clock with a date in loc (nil means time.Local), resolving DST changes with policy.
+func (t NullTimeOfDay) On(date NullDate, loc *time.Location, policy CalendarPolicy) (sql.NullTime, error) {
+	if !t.Valid || !date.Valid {
+		return NilTime(), nil
+	}
+
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if now, err := LocalDate(date.Year, date.Month, date.Day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc, policy); err != nil {
+		return NilTime(), err
+	} else {
+		return validTime(now), nil
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Add wraps around midnight, 23:00 plus two hours is 01:00.
+func (t NullTimeOfDay) Add(d time.Duration) NullTimeOfDay {
+	if !t.Valid {
+		return t
+	}
+	return NewTimeOfDay(t.SinceMidnight + d)
+}
+
+// Sub returns t minus other on the same day, zero when either side is invalid.
+func (t NullTimeOfDay) Sub(other NullTimeOfDay) time.Duration {
+	if !t.Valid || !other.Valid {
+		return 0
+	}
+	return t.SinceMidnight - other.SinceMidnight
+}
+
+func (t NullTimeOfDay) Before(other NullTimeOfDay) bool {
+	return t.Valid && other.Valid && t.SinceMidnight < other.SinceMidnight
+}
+
+func (t NullTimeOfDay) After(other NullTimeOfDay) bool {
+	return t.Valid && other.Valid && t.SinceMidnight > other.SinceMidnight
+}
+
+func (t NullTimeOfDay) Equal(other NullTimeOfDay) bool {
+	if !t.Valid || !other.Valid {
+		return t.Valid == other.Valid
+	}
+	return t.SinceMidnight == other.SinceMidnight
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (t *NullTimeOfDay) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*t = NilTimeOfDay()
+		return nil
+	case time.Time:
+		*t = TimeOfDayOf(value)
+		return nil
+	case []byte:
+		return t.scanString(string(value))
+	case string:
+		return t.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into NullTimeOfDay", value)
+}
+
+func (t *NullTimeOfDay) scanString(value string) error {
+	if now, err := ParseTimeOfDay(value); err != nil {
+		return err
+	} else {
+		*t = now
+		return nil
+	}
+}
+
+func (t NullTimeOfDay) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return FormatFineTimeOfDay(t), nil
+}
+
+func (t NullTimeOfDay) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FormatFineTimeOfDay(t))
+}
+
+func (t *NullTimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = NilTimeOfDay()
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.scanString(value)
+}
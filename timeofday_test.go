@@ -0,0 +1,45 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_timeofday_NullTimeOfDay(t *testing.T) {
+	a := assert.New(t)
+
+	opensAt, err := ParseTimeOfDay("09:30")
+	a.Nil(err)
+	a.Equal("09:30:00", FormatTimeOfDay(opensAt))
+
+	closesAt, err := ParseTimeOfDay("17:45:30.5")
+	a.Nil(err)
+	a.Equal("17:45:30.5", closesAt.String())
+	a.Equal(17, closesAt.Hour())
+	a.Equal(500000000, closesAt.Nanosecond())
+
+	a.True(opensAt.Before(closesAt))
+	a.Equal(8*time.Hour+15*time.Minute+30*time.Second+500*time.Millisecond, closesAt.Sub(opensAt))
+	a.Equal("01:00:00", FormatTimeOfDay(TimeOfDay(23, 0, 0, 0).Add(2*time.Hour)))
+	a.Equal("23:00:00", FormatTimeOfDay(TimeOfDay(1, 0, 0, 0).Add(-2*time.Hour)))
+
+	_, err = ParseTimeOfDay("25:00")
+	a.NotNil(err)
+
+	now, err := opensAt.On(Date(2021, 1, 2), time.UTC, DefaultCalendarPolicy)
+	a.Nil(err)
+	a.Equal("2021-01-02 09:30:00", Format(now))
+
+	var scanned NullTimeOfDay
+	a.Nil(scanned.Scan([]byte("08:00:00")))
+	a.Equal(TimeOfDay(8, 0, 0, 0), scanned)
+
+	value, err := scanned.Value()
+	a.Nil(err)
+	a.Equal("08:00:00", value)
+
+	a.Nil(scanned.Scan(nil))
+	a.False(scanned.Valid)
+	a.False(scanned.Add(time.Hour).Valid)
+}
@@ -99,6 +99,7 @@ func (t *Timestamps) GetDeletedAt() time.Time {
 	return t.DeletedAt.Time
 }
 
+// SetCreatedAt applies DefaultPrecision, SetCreatedAtSqlTime stores the time as it is.
 func (t *Timestamps) SetCreatedAt(now time.Time) {
 	t.CreatedAt = Time(now)
 }
@@ -138,6 +139,18 @@ func (t *Timestamps) SetDeletedAtSqlTime(now sql.NullTime) {
 	t.DeletedAt = now
 }
 
+func (t *Timestamps) GetCreatedAtNullTime() NullTime {
+	return NewNullTime(t.CreatedAt)
+}
+
+func (t *Timestamps) GetUpdatedAtNullTime() NullTime {
+	return NewNullTime(t.UpdatedAt)
+}
+
+func (t *Timestamps) GetDeletedAtNullTime() NullTime {
+	return NewNullTime(t.DeletedAt)
+}
+
 ///////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////
 ///////////////////////////////////////////////////////////////
@@ -427,16 +440,36 @@ func (t *Timestamps) GetDeletedAtWithLayout(layout string) string {
 	return FormatWithLayout(layout, t.DeletedAt)
 }
 
+//////////////////////////////////////////////
+//////////////////////////////////////////////
+//////////////////////////////////////////////
+func (t *Timestamps) GetCreatedAtRelative() string {
+	return FormatRelative(t.CreatedAt, time.Now())
+}
+
+func (t *Timestamps) GetUpdatedAtRelative() string {
+	return FormatRelative(t.UpdatedAt, time.Now())
+}
+
+func (t *Timestamps) GetDeletedAtRelative() string {
+	return FormatRelative(t.DeletedAt, time.Now())
+}
+
 //////////////////////////////////////////////
 //////////////////////////////////////////////
 //////////////////////////////////////////////
 func (t *Timestamps) LoadDefaultTimestamps() {
+	t.LoadDefaultTimestampsWithPrecision(DefaultPrecision)
+}
+
+// LoadDefaultTimestampsWithPrecision is LoadDefaultTimestamps for a model whose columns differ from DefaultPrecision.
+func (t *Timestamps) LoadDefaultTimestampsWithPrecision(p Precision) {
 	if !t.CreatedAt.Valid {
-		t.CreatedAt = Now()
+		t.CreatedAt = p.now()
 	}
 
 	if !t.UpdatedAt.Valid {
-		t.UpdatedAt = Now()
+		t.UpdatedAt = p.now()
 	}
 }
 
@@ -445,13 +478,25 @@ func (t *Timestamps) IsDelete() bool {
 }
 
 func (t *Timestamps) TouchCreateTimestamps() {
-	t.CreatedAt = Now()
+	t.TouchCreateTimestampsWithPrecision(DefaultPrecision)
 }
 
 func (t *Timestamps) TouchUpdateTimestamps() {
-	t.UpdatedAt = Now()
+	t.TouchUpdateTimestampsWithPrecision(DefaultPrecision)
 }
 
 func (t *Timestamps) TouchDeleteTimestamps() {
-	t.DeletedAt = Now()
+	t.TouchDeleteTimestampsWithPrecision(DefaultPrecision)
+}
+
+func (t *Timestamps) TouchCreateTimestampsWithPrecision(p Precision) {
+	t.CreatedAt = p.now()
+}
+
+func (t *Timestamps) TouchUpdateTimestampsWithPrecision(p Precision) {
+	t.UpdatedAt = p.now()
+}
+
+func (t *Timestamps) TouchDeleteTimestampsWithPrecision(p Precision) {
+	t.DeletedAt = p.now()
 }
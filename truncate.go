@@ -0,0 +1,155 @@
+package timestamps
+
+import (
+	"database/sql"
+	"time"
+)
+
+type CalendarUnit int
+
+const (
+	CalendarHour CalendarUnit = iota
+	CalendarDay
+	// CalendarWeek is the ISO week starting on Monday.
+	CalendarWeek
+	CalendarMonth
+	CalendarQuarter
+	CalendarYear
+)
+
+var calendarUnitNames = map[string]CalendarUnit{
+	"hour":    CalendarHour,
+	"day":     CalendarDay,
+	"week":    CalendarWeek,
+	"month":   CalendarMonth,
+	"quarter": CalendarQuarter,
+	"year":    CalendarYear,
+}
+
+// floorCalendar returns the start of the unit containing now, evaluated in the location of now.
+// A midnight skipped by a DST change resolves to the first instant of the day.
+func floorCalendar(now time.Time, unit CalendarUnit) time.Time {
+	if unit == CalendarHour {
+		return now.Add(-time.Duration(now.Minute())*time.Minute - time.Duration(now.Second())*time.Second - time.Duration(now.Nanosecond()))
+	}
+
+	year, month, day := now.Date()
+	switch unit {
+	case CalendarWeek:
+		day -= (int(now.Weekday()) + 6) % 7
+	case CalendarMonth:
+		day = 1
+	case CalendarQuarter:
+		month, day = (month-1)/3*3+1, 1
+	case CalendarYear:
+		month, day = time.January, 1
+	}
+	return calendarMidnight(year, month, day, now.Location())
+}
+
+// addCalendarUnits moves a unit boundary produced by floorCalendar by n units.
+func addCalendarUnits(boundary time.Time, unit CalendarUnit, n int) time.Time {
+	if unit == CalendarHour {
+		return boundary.Add(time.Duration(n) * time.Hour)
+	}
+
+	year, month, day := boundary.Date()
+	switch unit {
+	case CalendarDay:
+		day += n
+	case CalendarWeek:
+		day += 7 * n
+	case CalendarMonth:
+		month += time.Month(n)
+	case CalendarQuarter:
+		month += time.Month(3 * n)
+	case CalendarYear:
+		year += n
+	}
+	return calendarMidnight(year, month, day, boundary.Location())
+}
+
+func calendarMidnight(year int, month time.Month, day int, loc *time.Location) time.Time {
+	midnight, _ := LocalDate(year, month, day, 0, 0, 0, 0, loc, DefaultCalendarPolicy)
+	return midnight
+}
+
+func calendarLocation(t sql.NullTime, loc *time.Location) time.Time {
+	if loc == nil {
+		return t.Time
+	}
+	return t.Time.In(loc)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Floor, Ceil and Round evaluate calendar units in loc, nil means the location of t.
+func Floor(t sql.NullTime, unit CalendarUnit, loc *time.Location) sql.NullTime {
+	if !t.Valid {
+		return t
+	}
+	return validTime(floorCalendar(calendarLocation(t, loc), unit))
+}
+
+func Ceil(t sql.NullTime, unit CalendarUnit, loc *time.Location) sql.NullTime {
+	if !t.Valid {
+		return t
+	}
+
+	now := calendarLocation(t, loc)
+	if floor := floorCalendar(now, unit); floor.Equal(now) {
+		return validTime(floor)
+	} else {
+		return validTime(addCalendarUnits(floor, unit, 1))
+	}
+}
+
+func Round(t sql.NullTime, unit CalendarUnit, loc *time.Location) sql.NullTime {
+	if !t.Valid {
+		return t
+	}
+
+	now := calendarLocation(t, loc)
+	floor := floorCalendar(now, unit)
+	ceil := addCalendarUnits(floor, unit, 1)
+	if now.Sub(floor) < ceil.Sub(now) {
+		return validTime(floor)
+	}
+	return validTime(ceil)
+}
+
+// BucketOf returns the unit containing t, EndedAt is the exclusive start of the next unit.
+func BucketOf(t sql.NullTime, unit CalendarUnit, loc *time.Location) Duration {
+	if !t.Valid {
+		return Duration{}
+	}
+
+	floor := floorCalendar(calendarLocation(t, loc), unit)
+	return Duration{StartedAt: validTime(floor), EndedAt: validTime(addCalendarUnits(floor, unit, 1))}
+}
+
+// Bucket returns the whole units covering the duration, an open end is covered by the unit of the other bound.
+func (t *Duration) Bucket(unit CalendarUnit, loc *time.Location) Duration {
+	if !t.StartedAt.Valid {
+		return BucketOf(t.EndedAt, unit, loc)
+	}
+
+	bucket := BucketOf(t.StartedAt, unit, loc)
+	if ceil := Ceil(t.EndedAt, unit, loc); ceil.Valid && ceil.Time.After(bucket.EndedAt.Time) {
+		bucket.EndedAt = ceil
+	}
+	return bucket
+}
+
+func (t NullTime) Floor(unit CalendarUnit, loc *time.Location) NullTime {
+	return NewNullTime(Floor(t.NullTime, unit, loc))
+}
+
+func (t NullTime) Ceil(unit CalendarUnit, loc *time.Location) NullTime {
+	return NewNullTime(Ceil(t.NullTime, unit, loc))
+}
+
+func (t NullTime) Round(unit CalendarUnit, loc *time.Location) NullTime {
+	return NewNullTime(Round(t.NullTime, unit, loc))
+}
@@ -0,0 +1,72 @@
+package timestamps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_truncate_FloorCeilRound(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	a.Nil(err)
+
+	// 2021-08-19 01:30 in Shanghai is still 2021-08-18 in UTC.
+	now := Time(time.Date(2021, 8, 18, 17, 30, 0, 0, time.UTC))
+
+	cases := []struct {
+		unit  CalendarUnit
+		floor string
+		ceil  string
+		round string
+	}{
+		{CalendarHour, "2021-08-19 01:00:00", "2021-08-19 02:00:00", "2021-08-19 02:00:00"},
+		{CalendarDay, "2021-08-19 00:00:00", "2021-08-20 00:00:00", "2021-08-19 00:00:00"},
+		{CalendarWeek, "2021-08-16 00:00:00", "2021-08-23 00:00:00", "2021-08-16 00:00:00"},
+		{CalendarMonth, "2021-08-01 00:00:00", "2021-09-01 00:00:00", "2021-09-01 00:00:00"},
+		{CalendarQuarter, "2021-07-01 00:00:00", "2021-10-01 00:00:00", "2021-10-01 00:00:00"},
+		{CalendarYear, "2021-01-01 00:00:00", "2022-01-01 00:00:00", "2022-01-01 00:00:00"},
+	}
+
+	for _, c := range cases {
+		a.Equal(c.floor, Format(Floor(now, c.unit, loc)))
+		a.Equal(c.ceil, Format(Ceil(now, c.unit, loc)))
+		a.Equal(c.round, Format(Round(now, c.unit, loc)))
+	}
+
+	a.Equal("2021-08-18 00:00:00", Format(Floor(now, CalendarDay, time.UTC)))
+	a.True(Ceil(Floor(now, CalendarDay, loc), CalendarDay, loc).Time.Equal(Floor(now, CalendarDay, loc).Time))
+	a.False(Floor(NilTime(), CalendarDay, loc).Valid)
+}
+
+func Test_truncate_DST(t *testing.T) {
+	a := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	a.Nil(err)
+
+	now := Time(time.Date(2021, 3, 14, 12, 0, 0, 0, loc))
+	bucket := BucketOf(now, CalendarDay, nil)
+	a.Equal("2021-03-14 00:00:00 -05:00", FormatWithZone(bucket.StartedAt))
+	a.Equal("2021-03-15 00:00:00 -04:00", FormatWithZone(bucket.EndedAt))
+	a.Equal(int64(23*time.Hour), bucket.GetDurationLength())
+}
+
+func Test_truncate_Bucket(t *testing.T) {
+	a := assert.New(t)
+
+	duration := Duration{
+		StartedAt: Time(time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)),
+	}
+
+	bucket := duration.Bucket(CalendarMonth, time.UTC)
+	a.Equal("2021-01-01 00:00:00", Format(bucket.StartedAt))
+	a.Equal("2021-04-01 00:00:00", Format(bucket.EndedAt))
+
+	duration.EndedAt = NilTime()
+	bucket = duration.Bucket(CalendarQuarter, time.UTC)
+	a.Equal("2021-01-01 00:00:00", Format(bucket.StartedAt))
+	a.Equal("2021-04-01 00:00:00", Format(bucket.EndedAt))
+}
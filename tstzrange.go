@@ -0,0 +1,308 @@
+package timestamps
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRange = errors.New("timestamps: invalid range literal")
+
+const tstzRangeEmpty = "empty"
+const tstzRangeInfinity = "infinity"
+const tstzRangeNegativeInfinity = "-infinity"
+const tstzRangeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// tstzRangeMaxYear is the last year of a PostgreSQL timestamp.
+const tstzRangeMaxYear = 294276
+
+var tstzRangeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	time.RFC3339Nano,
+}
+
+// TstzRange is a PostgreSQL tstzrange column, e.g. ["2021-01-01 00:00:00+00","2021-02-01 00:00:00+00").
+// An invalid StartedAt or EndedAt is an unbounded side, unless LowerInfinite or UpperInfinite marks it as -infinity
+// or infinity. The Duration is a named field, so the encodings of Duration do not drop the bounds.
+type TstzRange struct {
+	Duration Duration
+	// LowerInclusive and UpperInclusive are "[" and "]", PostgreSQL makes unbounded sides exclusive.
+	LowerInclusive bool
+	UpperInclusive bool
+	// LowerInfinite is a -infinity lower bound and UpperInfinite an infinity upper bound, both contain every time
+	// like an unbounded side but are written back as they were read.
+	LowerInfinite bool
+	UpperInfinite bool
+	// Empty is the range that contains no time at all.
+	Empty bool
+	// Valid is false for NULL.
+	Valid bool
+}
+
+func NilTstzRange() TstzRange {
+	return TstzRange{}
+}
+
+func EmptyTstzRange() TstzRange {
+	return TstzRange{Empty: true, Valid: true}
+}
+
+// NewTstzRange returns d as "[)", like the tstzrange(lower, upper) constructor.
+func NewTstzRange(d Duration) TstzRange {
+	return TstzRange{
+		Duration:       d,
+		LowerInclusive: d.StartedAt.Valid,
+		Valid:          true,
+	}
+}
+
+// Contains tells whether now is in r, honouring the bound inclusivity.
+func (r TstzRange) Contains(now time.Time) bool {
+	if !r.Valid || r.Empty {
+		return false
+	}
+
+	if start := r.Duration.StartedAt; start.Valid {
+		if now.Before(start.Time) || (!r.LowerInclusive && now.Equal(start.Time)) {
+			return false
+		}
+	}
+	if end := r.Duration.EndedAt; end.Valid {
+		if now.After(end.Time) || (!r.UpperInclusive && now.Equal(end.Time)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r TstzRange) String() string {
+	return FormatTstzRange(r)
+}
+
+func ParseTstzRange(value string) (TstzRange, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, tstzRangeEmpty) {
+		return EmptyTstzRange(), nil
+	}
+
+	if 2 > len(value) {
+		return TstzRange{}, fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+
+	r := TstzRange{Valid: true}
+	switch value[0] {
+	case '[':
+		r.LowerInclusive = true
+	case '(':
+	default:
+		return TstzRange{}, fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+	switch value[len(value)-1] {
+	case ']':
+		r.UpperInclusive = true
+	case ')':
+	default:
+		return TstzRange{}, fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+
+	lower, rest, err := readTstzRangeBound(value[1 : len(value)-1])
+	if err != nil {
+		return TstzRange{}, fmt.Errorf("%w: %q: %v", ErrInvalidRange, value, err)
+	}
+	if 0 >= len(rest) || rest[0] != ',' {
+		return TstzRange{}, fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+	upper, rest, err := readTstzRangeBound(rest[1:])
+	if err != nil {
+		return TstzRange{}, fmt.Errorf("%w: %q: %v", ErrInvalidRange, value, err)
+	}
+	if 0 < len(rest) {
+		return TstzRange{}, fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+
+	if r.Duration.StartedAt, r.LowerInfinite, err = parseTstzRangeTime(lower, tstzRangeNegativeInfinity); err != nil {
+		return TstzRange{}, fmt.Errorf("%w: %q: %v", ErrInvalidRange, value, err)
+	}
+	if r.Duration.EndedAt, r.UpperInfinite, err = parseTstzRangeTime(upper, tstzRangeInfinity); err != nil {
+		return TstzRange{}, fmt.Errorf("%w: %q: %v", ErrInvalidRange, value, err)
+	}
+
+	if !r.Duration.StartedAt.Valid && !r.LowerInfinite {
+		r.LowerInclusive = false
+	}
+	if !r.Duration.EndedAt.Valid && !r.UpperInfinite {
+		r.UpperInclusive = false
+	}
+	return r, nil
+}
+
+// readTstzRangeBound reads one bound up to the next unquoted ',' or the end, quotes and backslashes escape.
+func readTstzRangeBound(value string) (string, string, error) {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(value) {
+				return "", "", errors.New("trailing backslash")
+			}
+			i++
+			b.WriteByte(value[i])
+		case c == '"':
+			if quoted && i+1 < len(value) && value[i+1] == '"' {
+				i++
+				b.WriteByte('"')
+				continue
+			}
+			quoted = !quoted
+		case c == ',' && !quoted:
+			return b.String(), value[i:], nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	if quoted {
+		return "", "", errors.New("unterminated quote")
+	}
+	return b.String(), "", nil
+}
+
+// parseTstzRangeTime reads one bound, infinity is the infinite value allowed on that side.
+func parseTstzRangeTime(value string, infinity string) (sql.NullTime, bool, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "":
+		return NilTime(), false, nil
+	case infinity:
+		return NilTime(), true, nil
+	case tstzRangeInfinity, tstzRangeNegativeInfinity:
+		return NilTime(), false, fmt.Errorf("%s is not supported as this bound", value)
+	}
+
+	// The year field is read by hand, Go layouts stop at four digits while PostgreSQL goes up to 294276. Dates
+	// before the common era come with a " BC" suffix, year 1 BC is year 0. The year field is swapped for one with
+	// the same leap day before parsing, so that February 29 of any leap year is read as it is.
+	bc := strings.HasSuffix(value, " BC")
+	value = strings.TrimSuffix(value, " BC")
+	dash := strings.IndexByte(value, '-')
+	if 0 >= dash || 6 < dash {
+		return NilTime(), false, fmt.Errorf("invalid year %q", value)
+	}
+	year, ok := parseFastDigits(value[:dash])
+	if !ok || tstzRangeMaxYear < year || (bc && 1 > year) {
+		return NilTime(), false, fmt.Errorf("invalid year %q", value)
+	}
+	if bc {
+		year = 1 - year
+	}
+	value = fmt.Sprintf("%04d", tstzRangeLeapYear(year)) + value[dash:]
+
+	var err error
+	for _, layout := range tstzRangeLayouts {
+		var now time.Time
+		if now, err = time.Parse(layout, value); err == nil {
+			if now.Year() != year {
+				// The parsed offset is kept as it is, the zone Parse may have matched it to could differ in year.
+				name, offset := now.Zone()
+				now = time.Date(year, now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.FixedZone(name, offset))
+			}
+			return validTime(now), false, nil
+		}
+	}
+	return NilTime(), false, err
+}
+
+// tstzRangeLeapYear returns a year of the common era that has a February 29 exactly when year has one.
+func tstzRangeLeapYear(year int) int {
+	if 0 == year%4 && (0 != year%100 || 0 == year%400) {
+		return 2000
+	}
+	return 2001
+}
+
+// FormatTstzRange writes the range literal, NULL is the empty string.
+func FormatTstzRange(r TstzRange) string {
+	if !r.Valid {
+		return ""
+	}
+	if r.Empty {
+		return tstzRangeEmpty
+	}
+
+	var b strings.Builder
+	if r.LowerInclusive && (r.Duration.StartedAt.Valid || r.LowerInfinite) {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	writeTstzRangeTime(&b, r.Duration.StartedAt, r.LowerInfinite, tstzRangeNegativeInfinity)
+	b.WriteByte(',')
+	writeTstzRangeTime(&b, r.Duration.EndedAt, r.UpperInfinite, tstzRangeInfinity)
+	if r.UpperInclusive && (r.Duration.EndedAt.Valid || r.UpperInfinite) {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+func writeTstzRangeTime(b *strings.Builder, t sql.NullTime, infinite bool, infinity string) {
+	if !t.Valid {
+		if infinite {
+			b.WriteString(`"` + infinity + `"`)
+		}
+		return
+	}
+
+	b.WriteByte('"')
+	if now := t.Time; now.Year() > 0 {
+		b.WriteString(now.Format(tstzRangeLayout))
+	} else {
+		// The year field is written as years BC on a year with the same leap day, see parseTstzRangeTime.
+		_, offset := now.Zone()
+		leap := time.Date(tstzRangeLeapYear(now.Year()), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.FixedZone("", offset))
+		b.WriteString(fmt.Sprintf("%04d", 1-now.Year()))
+		b.WriteString(leap.Format(tstzRangeLayout)[4:])
+		b.WriteString(" BC")
+	}
+	b.WriteByte('"')
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (r *TstzRange) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*r = NilTstzRange()
+		return nil
+	case []byte:
+		return r.scanString(string(value))
+	case string:
+		return r.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into TstzRange", value)
+}
+
+func (r *TstzRange) scanString(value string) error {
+	if tstzRange, err := ParseTstzRange(value); err != nil {
+		return err
+	} else {
+		*r = tstzRange
+		return nil
+	}
+}
+
+func (r TstzRange) Value() (driver.Value, error) {
+	if !r.Valid {
+		return nil, nil
+	}
+	return FormatTstzRange(r), nil
+}
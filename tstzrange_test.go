@@ -0,0 +1,170 @@
+package timestamps
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_tstzrange_ParseTstzRange(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := ParseTstzRange(`["2021-01-01 00:00:00+00","2021-02-01 00:00:00+00")`)
+	a.Nil(err)
+	a.True(r.Valid)
+	a.False(r.Empty)
+	a.True(r.LowerInclusive)
+	a.False(r.UpperInclusive)
+	a.True(r.Duration.StartedAt.Time.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	a.True(r.Duration.EndedAt.Time.Equal(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	r, err = ParseTstzRange(`("2021-01-01 08:00:00.123456+08","2021-01-01 05:30:00+05:30"]`)
+	a.Nil(err)
+	a.False(r.LowerInclusive)
+	a.True(r.UpperInclusive)
+	a.Equal(123456000, r.Duration.StartedAt.Time.Nanosecond())
+	a.True(r.Duration.StartedAt.Time.Add(-123456 * time.Microsecond).Equal(r.Duration.EndedAt.Time))
+
+	r, err = ParseTstzRange(`[2021-01-01T00:00:00Z,)`)
+	a.Nil(err)
+	a.True(r.Duration.StartedAt.Valid)
+	a.False(r.Duration.EndedAt.Valid)
+	a.False(r.UpperInclusive)
+
+	r, err = ParseTstzRange(`[-infinity,infinity]`)
+	a.Nil(err)
+	a.True(r.Valid)
+	a.False(r.Duration.StartedAt.Valid || r.Duration.EndedAt.Valid)
+	a.True(r.LowerInfinite && r.UpperInfinite)
+	a.True(r.LowerInclusive && r.UpperInclusive)
+	a.True(r.Contains(time.Now()))
+
+	r, err = ParseTstzRange(`["0044-03-15 12:00:00+00 BC",)`)
+	a.Nil(err)
+	a.Equal(-43, r.Duration.StartedAt.Time.Year())
+
+	// Leap days BC fall on years BC divisible by four plus one, 1 BC is year 0.
+	r, err = ParseTstzRange(`["0001-02-29 12:00:00+00 BC","0005-02-29 12:00:00+00 BC")`)
+	a.Nil(err)
+	a.Equal(time.Date(0, 2, 29, 12, 0, 0, 0, time.UTC), r.Duration.StartedAt.Time.UTC())
+	a.Equal(time.Date(-4, 2, 29, 12, 0, 0, 0, time.UTC), r.Duration.EndedAt.Time.UTC())
+
+	// Years past 9999 have more than four digits, 12024 is a leap year.
+	r, err = ParseTstzRange(`["10000-01-01 00:00:00+00","12024-02-29 12:00:00+08")`)
+	a.Nil(err)
+	a.Equal(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), r.Duration.StartedAt.Time.UTC())
+	a.Equal(time.Date(12024, 2, 29, 4, 0, 0, 0, time.UTC), r.Duration.EndedAt.Time.UTC())
+
+	r, err = ParseTstzRange("empty")
+	a.Nil(err)
+	a.Equal(EmptyTstzRange(), r)
+
+	for _, value := range []string{
+		"",
+		"[",
+		"{2021-01-01,2021-01-02)",
+		`["2021-01-01 00:00:00+00")`,
+		`["2021-01-01 00:00:00+00","2021-02-01 00:00:00+00",)`,
+		`["2021-01-01 00:00:00+00,)`,
+		`[yesterday,)`,
+		`[2021-01-01\`,
+		`[infinity,)`,
+		`(,-infinity)`,
+		`["0002-02-29 12:00:00+00 BC",)`,
+		`["0000-01-01 12:00:00+00 BC",)`,
+		`["12023-02-29 12:00:00+00",)`,
+		`["294277-01-01 00:00:00+00",)`,
+		`["99999999999999999999-01-01 00:00:00+00",)`,
+	} {
+		_, err := ParseTstzRange(value)
+		a.True(errors.Is(err, ErrInvalidRange), value)
+	}
+}
+
+func Test_tstzrange_FormatTstzRange(t *testing.T) {
+	a := assert.New(t)
+
+	r := NewTstzRange(Duration{
+		StartedAt: Time(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndedAt:   Time(time.Date(2021, 2, 1, 8, 0, 0, 500000000, time.FixedZone("CST", 8*3600))),
+	})
+	a.Equal(`["2021-01-01 00:00:00+00:00","2021-02-01 08:00:00.5+08:00")`, r.String())
+
+	r.UpperInclusive = true
+	r.Duration.StartedAt = NilTime()
+	a.Equal(`(,"2021-02-01 08:00:00.5+08:00"]`, FormatTstzRange(r))
+
+	back, err := ParseTstzRange(FormatTstzRange(r))
+	a.Nil(err)
+	a.Equal(r.UpperInclusive, back.UpperInclusive)
+	a.True(back.Duration.EndedAt.Time.Equal(r.Duration.EndedAt.Time))
+
+	r.Duration.StartedAt = Time(time.Date(-43, 3, 15, 12, 0, 0, 0, time.UTC))
+	a.Equal(`["0044-03-15 12:00:00+00:00 BC","2021-02-01 08:00:00.5+08:00"]`, FormatTstzRange(r))
+
+	r.Duration.StartedAt = Time(time.Date(0, 2, 29, 12, 0, 0, 0, time.FixedZone("CST", 8*3600)))
+	a.Equal(`["0001-02-29 12:00:00+08:00 BC","2021-02-01 08:00:00.5+08:00"]`, FormatTstzRange(r))
+	back, err = ParseTstzRange(FormatTstzRange(r))
+	a.Nil(err)
+	a.True(back.Duration.StartedAt.Time.Equal(r.Duration.StartedAt.Time))
+
+	r.Duration.EndedAt = Time(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.Equal(`["0001-02-29 12:00:00+08:00 BC","10000-01-01 00:00:00+00:00"]`, FormatTstzRange(r))
+	back, err = ParseTstzRange(FormatTstzRange(r))
+	a.Nil(err)
+	a.True(back.Duration.EndedAt.Time.Equal(r.Duration.EndedAt.Time))
+
+	// Infinite bounds are written back as they were read, not as unbounded sides.
+	for _, value := range []string{
+		`["-infinity","2021-01-01 00:00:00+00:00")`,
+		`("2021-01-01 00:00:00+00:00","infinity"]`,
+		`["-infinity","infinity"]`,
+	} {
+		back, err := ParseTstzRange(value)
+		a.Nil(err)
+		a.Equal(value, FormatTstzRange(back))
+	}
+
+	a.Equal("empty", FormatTstzRange(EmptyTstzRange()))
+	a.Equal("", FormatTstzRange(NilTstzRange()))
+	a.Equal("(,)", FormatTstzRange(NewTstzRange(Duration{})))
+}
+
+func Test_tstzrange_Scan(t *testing.T) {
+	a := assert.New(t)
+
+	r := TstzRange{}
+	a.Nil(r.Scan([]byte(`["2021-01-01 00:00:00+00","2021-02-01 00:00:00+00")`)))
+	a.True(r.Valid)
+
+	value, err := r.Value()
+	a.Nil(err)
+	a.Equal(driver.Value(`["2021-01-01 00:00:00+00:00","2021-02-01 00:00:00+00:00")`), value)
+
+	a.True(r.Contains(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	a.True(r.Contains(time.Date(2021, 1, 31, 23, 59, 59, 0, time.UTC)))
+	a.False(r.Contains(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)))
+	a.False(r.Contains(time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC)))
+
+	a.Nil(r.Scan("empty"))
+	a.True(r.Empty)
+	a.False(r.Contains(time.Now()))
+	value, err = r.Value()
+	a.Nil(err)
+	a.Equal(driver.Value("empty"), value)
+
+	a.Nil(r.Scan("(,)"))
+	a.True(r.Contains(time.Now()))
+
+	a.Nil(r.Scan(nil))
+	a.False(r.Valid)
+	a.False(r.Contains(time.Now()))
+	value, err = r.Value()
+	a.Nil(err)
+	a.Nil(value)
+
+	a.NotNil(r.Scan(42))
+	a.NotNil(r.Scan("[,"))
+}
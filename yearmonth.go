@@ -0,0 +1,194 @@
+package timestamps
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const DefaultYearMonthLayout = "2006-01"
+
+// YearMonth is a calendar month such as "2021-07", for invoices and monthly reports.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+	Valid bool
+}
+
+func NilYearMonth() YearMonth {
+	return YearMonth{}
+}
+
+func NewYearMonth(year int, month time.Month) YearMonth {
+	return YearMonthOf(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func YearMonthOf(now time.Time) YearMonth {
+	return YearMonth{Year: now.Year(), Month: now.Month(), Valid: true}
+}
+
+func ParseYearMonth(value string) (YearMonth, error) {
+	if 0 >= len(value) {
+		return NilYearMonth(), nil
+	}
+
+	if now, err := time.Parse(DefaultYearMonthLayout, value); err != nil {
+		return NilYearMonth(), err
+	} else {
+		return YearMonthOf(now), nil
+	}
+}
+
+func FormatYearMonth(ym YearMonth) string {
+	if !ym.Valid {
+		return ""
+	}
+	return ym.first().Format(DefaultYearMonthLayout)
+}
+
+func (ym YearMonth) String() string {
+	return FormatYearMonth(ym)
+}
+
+func (ym YearMonth) first() time.Time {
+	return time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+func (ym YearMonth) AddMonths(months int) YearMonth {
+	if !ym.Valid {
+		return ym
+	}
+	return NewYearMonth(ym.Year, ym.Month+time.Month(months))
+}
+
+func (ym YearMonth) Next() YearMonth {
+	return ym.AddMonths(1)
+}
+
+func (ym YearMonth) Prev() YearMonth {
+	return ym.AddMonths(-1)
+}
+
+func (ym YearMonth) FirstDay() NullDate {
+	if !ym.Valid {
+		return NilDate()
+	}
+	return Date(ym.Year, ym.Month, 1)
+}
+
+func (ym YearMonth) LastDay() NullDate {
+	return ym.Next().FirstDay().AddDays(-1)
+}
+
+func (ym YearMonth) Quarter() Quarter {
+	return DefaultFiscalCalendar.QuarterOf(ym)
+}
+
+// Duration covers the month in loc (nil means time.Local), EndedAt is the first instant of the next month.
+func (ym YearMonth) Duration(loc *time.Location) Duration {
+	return Duration{
+		StartedAt: ym.FirstDay().In(loc),
+		EndedAt:   ym.Next().FirstDay().In(loc),
+	}
+}
+
+func (ym YearMonth) Compare(other YearMonth) int {
+	switch {
+	case !ym.Valid || !other.Valid:
+		if ym.Valid == other.Valid {
+			return 0
+		} else if ym.Valid {
+			return 1
+		}
+		return -1
+	case ym.Year != other.Year:
+		return compareInt(ym.Year, other.Year)
+	}
+	return compareInt(int(ym.Month), int(other.Month))
+}
+
+func compareInt(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+/////////////////////////////////////////////////////////////////
+// Scan accepts "2021-07", a date or datetime string starting with it, a time.Time or an integer such as 202107.
+func (ym *YearMonth) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*ym = NilYearMonth()
+		return nil
+	case time.Time:
+		*ym = YearMonthOf(value)
+		return nil
+	case int64:
+		if value < 100 || value%100 < 1 || value%100 > 12 {
+			return fmt.Errorf("timestamps: cannot scan %d into YearMonth", value)
+		}
+		*ym = NewYearMonth(int(value/100), time.Month(value%100))
+		return nil
+	case []byte:
+		return ym.scanString(string(value))
+	case string:
+		return ym.scanString(value)
+	}
+	return fmt.Errorf("timestamps: cannot scan %T into YearMonth", value)
+}
+
+func (ym *YearMonth) scanString(value string) error {
+	if len(DefaultYearMonthLayout) < len(value) {
+		value = value[:len(DefaultYearMonthLayout)]
+	}
+
+	if month, err := ParseYearMonth(value); err != nil {
+		return err
+	} else {
+		*ym = month
+		return nil
+	}
+}
+
+func (ym YearMonth) Value() (driver.Value, error) {
+	if !ym.Valid {
+		return nil, nil
+	}
+	return FormatYearMonth(ym), nil
+}
+
+func (ym YearMonth) MarshalJSON() ([]byte, error) {
+	if !ym.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FormatYearMonth(ym))
+}
+
+func (ym *YearMonth) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*ym = NilYearMonth()
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if month, err := ParseYearMonth(value); err != nil {
+		return err
+	} else {
+		*ym = month
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package timestamps
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_yearmonth_YearMonth(t *testing.T) {
+	a := assert.New(t)
+
+	ym, err := ParseYearMonth("2021-12")
+	a.Nil(err)
+	a.Equal("2022-01", ym.Next().String())
+	a.Equal("2021-11", ym.Prev().String())
+	a.Equal("2021-12-31", ym.LastDay().String())
+	a.Equal(1, ym.Compare(NewYearMonth(2021, 11)))
+	a.Equal(NewYearMonth(2022, 1), NewYearMonth(2021, 13))
+
+	duration := ym.Duration(time.UTC)
+	a.Equal("2021-12-01 00:00:00", Format(duration.StartedAt))
+	a.Equal("2022-01-01 00:00:00", Format(duration.EndedAt))
+
+	for _, value := range []interface{}{"2021-12", []byte("2021-12-05"), int64(202112), time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)} {
+		scanned := YearMonth{}
+		a.Nil(scanned.Scan(value), value)
+		a.Equal(ym, scanned)
+	}
+	a.NotNil((&YearMonth{}).Scan(int64(202113)))
+
+	stored, err := ym.Value()
+	a.Nil(err)
+	a.Equal("2021-12", stored)
+
+	data, err := json.Marshal([]YearMonth{ym, NilYearMonth()})
+	a.Nil(err)
+	a.Equal(`["2021-12",null]`, string(data))
+
+	var decoded []YearMonth
+	a.Nil(json.Unmarshal(data, &decoded))
+	a.Equal([]YearMonth{ym, NilYearMonth()}, decoded)
+}
+
+func Test_yearmonth_Quarter(t *testing.T) {
+	a := assert.New(t)
+
+	q, err := ParseQuarter("2021-Q4")
+	a.Nil(err)
+	a.Equal("2022-Q1", q.Next().String())
+	a.Equal("2021-Q3", q.Prev().String())
+	a.Equal(NewQuarter(2020, 4), NewQuarter(2021, 0))
+	a.Equal([]YearMonth{NewYearMonth(2021, 10), NewYearMonth(2021, 11), NewYearMonth(2021, 12)}, q.Months())
+	a.Equal(q, QuarterOf(time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC)))
+
+	duration := q.Duration(time.UTC)
+	a.Equal("2021-10-01 00:00:00", Format(duration.StartedAt))
+	a.Equal("2022-01-01 00:00:00", Format(duration.EndedAt))
+
+	for _, value := range []string{"2021", "2021-Q5", "Q4", "2021-Q44"} {
+		_, err := ParseQuarter(value)
+		a.NotNil(err, value)
+	}
+
+	// US federal fiscal year: FY2021 runs from October 2020 to September 2021.
+	federal := FiscalCalendar{StartMonth: time.October, NamedByEndYear: true}
+	a.Equal(NewQuarter(2021, 1), federal.QuarterOf(NewYearMonth(2020, 10)))
+	a.Equal(NewQuarter(2021, 4), federal.QuarterOf(NewYearMonth(2021, 9)))
+	a.Equal(NewYearMonth(2020, 10), federal.FirstMonth(NewQuarter(2021, 1)))
+
+	// Japanese fiscal year: FY2021 runs from April 2021 to March 2022.
+	japan := FiscalCalendar{StartMonth: time.April}
+	a.Equal(NewQuarter(2021, 4), japan.QuarterOf(NewYearMonth(2022, 3)))
+	a.Equal(NewYearMonth(2022, 1), japan.FirstMonth(NewQuarter(2021, 4)))
+
+	var scanned Quarter
+	a.Nil(scanned.Scan([]byte("2021q4")))
+	a.Equal(q, scanned)
+}